@@ -0,0 +1,66 @@
+package konfig
+
+import "strings"
+
+// aliases are the alternative names for the flag and environment variables of a field.
+// The names in deprecated are still read, but a warning is logged when they are used.
+type aliases struct {
+	flag       []string
+	env        []string
+	fileEnv    []string
+	deprecated []string
+}
+
+// splitNames splits the value of a flag, env, or fileenv tag into a name and its aliases.
+//
+//	LOG_LEVEL,LOGLEVEL  -->  LOG_LEVEL, [LOGLEVEL]
+//	,LOGLEVEL           -->  "", [LOGLEVEL]
+//
+// An empty name means the default name is used.
+func splitNames(val string) (string, []string) {
+	if val == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(val, ",")
+	name := strings.TrimSpace(parts[0])
+
+	// A skipped source does not have any alias
+	if name == skip {
+		return skip, nil
+	}
+
+	return name, splitList(strings.Join(parts[1:], ","))
+}
+
+// splitList splits a comma-separated list of names and removes empty names.
+func splitList(val string) []string {
+	var names []string
+	for _, name := range strings.Split(val, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// isDeprecated determines whether or not a name is deprecated.
+func (a aliases) isDeprecated(name string) bool {
+	return contains(a.deprecated, name)
+}
+
+// flagNames returns the name of the flag for a field followed by its aliases.
+func (p *fieldPlan) flagNames() []string {
+	return append([]string{p.flagName}, p.aliases.flag...)
+}
+
+// envNames returns the name of the environment variable for a field followed by its aliases.
+func (p *fieldPlan) envNames() []string {
+	return append([]string{p.envName}, p.aliases.env...)
+}
+
+// fileEnvNames returns the name of the file environment variable for a field followed by its aliases.
+func (p *fieldPlan) fileEnvNames() []string {
+	return append([]string{p.fileEnvName}, p.aliases.fileEnv...)
+}
@@ -0,0 +1,196 @@
+package konfig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitNames(t *testing.T) {
+	tests := []struct {
+		val             string
+		expectedName    string
+		expectedAliases []string
+	}{
+		{"", "", nil},
+		{"LOG_LEVEL", "LOG_LEVEL", nil},
+		{"LOG_LEVEL,LOGLEVEL", "LOG_LEVEL", []string{"LOGLEVEL"}},
+		{"LOG_LEVEL, LOGLEVEL, LEVEL", "LOG_LEVEL", []string{"LOGLEVEL", "LEVEL"}},
+		{",LOGLEVEL", "", []string{"LOGLEVEL"}},
+		{"LOG_LEVEL,,", "LOG_LEVEL", nil},
+		{"-", "-", nil},
+		{"-,LOGLEVEL", "-", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.val, func(t *testing.T) {
+			name, aliases := splitNames(tc.val)
+			assert.Equal(t, tc.expectedName, name)
+			assert.Equal(t, tc.expectedAliases, aliases)
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected []string
+	}{
+		{"", nil},
+		{" , ", nil},
+		{"LOGLEVEL", []string{"LOGLEVEL"}},
+		{"LOGLEVEL, loglevel", []string{"LOGLEVEL", "loglevel"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.val, func(t *testing.T) {
+			assert.Equal(t, tc.expected, splitList(tc.val))
+		})
+	}
+}
+
+func TestAliases(t *testing.T) {
+	p := &fieldPlan{
+		flagName:    "log.level",
+		envName:     "LOG_LEVEL",
+		fileEnvName: "LOG_LEVEL_FILE",
+		aliases: aliases{
+			flag:       []string{"loglevel"},
+			env:        []string{"LOGLEVEL"},
+			fileEnv:    []string{"LOGLEVEL_FILE"},
+			deprecated: []string{"LOGLEVEL"},
+		},
+	}
+
+	assert.Equal(t, []string{"log.level", "loglevel"}, p.flagNames())
+	assert.Equal(t, []string{"LOG_LEVEL", "LOGLEVEL"}, p.envNames())
+	assert.Equal(t, []string{"LOG_LEVEL_FILE", "LOGLEVEL_FILE"}, p.fileEnvNames())
+	assert.True(t, p.aliases.isDeprecated("LOGLEVEL"))
+	assert.False(t, p.aliases.isDeprecated("loglevel"))
+	assert.False(t, p.aliases.isDeprecated("LOG_LEVEL"))
+}
+
+func TestReaderGetFieldValueAliases(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+
+	p := &fieldPlan{
+		name:        "Field",
+		flagName:    "aliases.level",
+		envName:     "ALIASES_LEVEL",
+		fileEnvName: "ALIASES_LEVEL_FILE",
+		aliases: aliases{
+			flag:       []string{"aliases.loglevel"},
+			env:        []string{"ALIASES_LOGLEVEL"},
+			deprecated: []string{"ALIASES_LOGLEVEL"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		envs           map[string]string
+		expectedValue  string
+		expectedSource Source
+		expectedName   string
+	}{
+		{
+			"NoValue",
+			[]string{"app"},
+			nil,
+			"",
+			SourceDefault,
+			"",
+		},
+		{
+			"FromFlagAlias",
+			[]string{"app", "-aliases.loglevel=debug"},
+			map[string]string{"ALIASES_LEVEL": "info"},
+			"debug",
+			SourceFlag,
+			"aliases.loglevel",
+		},
+		{
+			"FromFlagBeforeAlias",
+			[]string{"app", "-aliases.loglevel=debug", "-aliases.level=warn"},
+			nil,
+			"warn",
+			SourceFlag,
+			"aliases.level",
+		},
+		{
+			"FromEnvAlias",
+			[]string{"app"},
+			map[string]string{"ALIASES_LOGLEVEL": "info"},
+			"info",
+			SourceEnv,
+			"ALIASES_LOGLEVEL",
+		},
+		{
+			"FromEnvBeforeAlias",
+			[]string{"app"},
+			map[string]string{"ALIASES_LEVEL": "error", "ALIASES_LOGLEVEL": "info"},
+			"error",
+			SourceEnv,
+			"ALIASES_LEVEL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, value := range tc.envs {
+				err := os.Setenv(name, value)
+				assert.NoError(t, err)
+				defer os.Unsetenv(name)
+			}
+
+			r := &reader{
+				args: parseFlagArgs(tc.args),
+			}
+
+			value, source, _, name := r.getFieldValue(p)
+			assert.Equal(t, tc.expectedValue, value)
+			assert.Equal(t, tc.expectedSource, source)
+			assert.Equal(t, tc.expectedName, name)
+		})
+	}
+}
+
+func TestPickAliases(t *testing.T) {
+	type config struct {
+		AliasedRegion string `env:",ALIASED_ZONE"`
+		AliasedPort   int    `env:"ALIASED_PORT,ALIASED_LISTEN_PORT" deprecated:"ALIASED_LISTEN_PORT"`
+	}
+
+	err := os.Setenv("ALIASED_ZONE", "us-east-1")
+	assert.NoError(t, err)
+	defer os.Unsetenv("ALIASED_ZONE")
+
+	err = os.Setenv("ALIASED_LISTEN_PORT", "8080")
+	assert.NoError(t, err)
+	defer os.Unsetenv("ALIASED_LISTEN_PORT")
+
+	var warnings []interface{}
+	logger := LoggerFunc(func(verbosity uint, msg string, keyvals ...interface{}) {
+		if msg == "deprecated name used" {
+			warnings = append(warnings, keyvals)
+		}
+	})
+
+	l := NewLoader(Logging(logger), Strict())
+	c := &config{}
+	err = l.Pick(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &config{AliasedRegion: "us-east-1", AliasedPort: 8080}, c)
+	assert.Equal(t, []interface{}{
+		[]interface{}{"field", "AliasedPort", "name", "ALIASED_LISTEN_PORT"},
+	}, warnings)
+	assert.Equal(t, []Field{
+		{Name: "AliasedRegion", Type: "string", Flag: "aliased.region", Env: "ALIASED_REGION", FileEnv: "ALIASED_REGION_FILE", Source: SourceEnv},
+		{Name: "AliasedPort", Type: "int", Flag: "aliased.port", Env: "ALIASED_PORT", FileEnv: "ALIASED_PORT_FILE", Source: SourceEnv, Deprecated: "ALIASED_LISTEN_PORT"},
+	}, l.Describe())
+}
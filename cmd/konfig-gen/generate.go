@@ -0,0 +1,674 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const konfigImport = "github.com/moorara/konfig"
+
+// baseType describes how a value of a supported type is parsed from a string.
+// The parsing is identical to the reflection path in reader_set.go.
+type baseType struct {
+	name      string // the Go type as printed by reflect (i.e. time.Duration)
+	imp       string // the import path needed for parsing a value
+	typeImp   string // the import path needed for declaring a list if it is different from imp
+	parse     string // the parsing call where %s is the string value
+	convert   string // the conversion of the parsed value where %s is the parsed value
+	ptrResult bool   // whether or not the parsing call returns a pointer
+	noSlice   bool   // whether or not lists of the type are skipped
+	layout    bool   // whether or not the parsing call takes the value of layout tag as the second argument
+	named     string // the named type declared in the package that the parsed value is converted to (i.e. Level)
+}
+
+var baseTypes = map[string]baseType{
+	"string":        {name: "string"},
+	"bool":          {name: "bool", imp: "strconv", parse: "strconv.ParseBool(%s)", convert: "%s"},
+	"float32":       {name: "float32", imp: "strconv", parse: "strconv.ParseFloat(%s, 32)", convert: "float32(%s)"},
+	"float64":       {name: "float64", imp: "strconv", parse: "strconv.ParseFloat(%s, 64)", convert: "%s"},
+	"complex64":     {name: "complex64", imp: "strconv", parse: "strconv.ParseComplex(%s, 64)", convert: "complex64(%s)"},
+	"complex128":    {name: "complex128", imp: "strconv", parse: "strconv.ParseComplex(%s, 128)", convert: "%s"},
+	"int":           {name: "int", imp: "strconv", parse: "strconv.ParseInt(%s, 10, 64)", convert: "int(%s)"},
+	"int8":          {name: "int8", imp: "strconv", parse: "strconv.ParseInt(%s, 10, 8)", convert: "int8(%s)"},
+	"int16":         {name: "int16", imp: "strconv", parse: "strconv.ParseInt(%s, 10, 16)", convert: "int16(%s)"},
+	"int32":         {name: "int32", imp: "strconv", parse: "strconv.ParseInt(%s, 10, 32)", convert: "int32(%s)"},
+	"int64":         {name: "int64", imp: "strconv", parse: "strconv.ParseInt(%s, 10, 64)", convert: "%s"},
+	"uint":          {name: "uint", imp: "strconv", parse: "strconv.ParseUint(%s, 10, 64)", convert: "uint(%s)"},
+	"uint8":         {name: "uint8", imp: "strconv", parse: "strconv.ParseUint(%s, 10, 8)", convert: "uint8(%s)"},
+	"uint16":        {name: "uint16", imp: "strconv", parse: "strconv.ParseUint(%s, 10, 16)", convert: "uint16(%s)"},
+	"uint32":        {name: "uint32", imp: "strconv", parse: "strconv.ParseUint(%s, 10, 32)", convert: "uint32(%s)"},
+	"uint64":        {name: "uint64", imp: "strconv", parse: "strconv.ParseUint(%s, 10, 64)", convert: "%s"},
+	"time.Duration": {name: "time.Duration", imp: "time", parse: "time.ParseDuration(%s)", convert: "%s"},
+	"net/url.URL":   {name: "url.URL", imp: "net/url", parse: "url.Parse(%s)", convert: "*%s", ptrResult: true},
+	"regexp.Regexp": {name: "regexp.Regexp", imp: "regexp", parse: "regexp.CompilePOSIX(%s)", convert: "*%s", ptrResult: true},
+
+	"crypto/tls.Certificate": {name: "tls.Certificate", parse: "konfig.ParseCertificate(%s)", convert: "*%s", ptrResult: true, noSlice: true},
+	"crypto/x509.CertPool":   {name: "x509.CertPool", parse: "konfig.ParseCertPool(%s)", convert: "*%s", ptrResult: true, noSlice: true},
+
+	"net.IP":             {name: "net.IP", typeImp: "net", parse: "konfig.ParseIP(%s)", convert: "%s"},
+	"net.IPNet":          {name: "net.IPNet", typeImp: "net", parse: "konfig.ParseIPNet(%s)", convert: "*%s", ptrResult: true},
+	"net/netip.Addr":     {name: "netip.Addr", imp: "net/netip", parse: "netip.ParseAddr(%s)", convert: "%s"},
+	"net/netip.Prefix":   {name: "netip.Prefix", imp: "net/netip", parse: "netip.ParsePrefix(%s)", convert: "%s"},
+	"net/netip.AddrPort": {name: "netip.AddrPort", imp: "net/netip", parse: "netip.ParseAddrPort(%s)", convert: "%s"},
+
+	"time.Time":      {name: "time.Time", typeImp: "time", parse: "konfig.ParseTime(%s, %s)", convert: "%s", layout: true},
+	"*time.Location": {name: "*time.Location", imp: "time", parse: "time.LoadLocation(%s)", convert: "%s"},
+
+	konfigImport + ".TimeOfDay": {name: "konfig.TimeOfDay", parse: "konfig.ParseTimeOfDay(%s)", convert: "%s"},
+	konfigImport + ".ByteSize":  {name: "konfig.ByteSize", parse: "konfig.ParseByteSize(%s)", convert: "%s"},
+	konfigImport + ".Quantity":  {name: "konfig.Quantity", parse: "konfig.ParseQuantity(%s)", convert: "%s"},
+}
+
+// Aliases for built-in types
+func init() {
+	baseTypes["byte"] = baseTypes["uint8"]
+	baseTypes["rune"] = baseTypes["int32"]
+}
+
+type fieldKind int
+
+const (
+	kindValue fieldKind = iota
+	kindPointer
+	kindSlice
+	kindArray
+	kindBytes
+	kindStructs
+	kindFormat
+)
+
+// field is a struct field that its value can be read.
+type field struct {
+	name   string
+	tag    string
+	kind   fieldKind
+	base   baseType
+	length string // the length of an array field
+}
+
+// dataType returns the Go type of the field as printed by reflect.
+func (f field) dataType() string {
+	switch f.kind {
+	case kindPointer:
+		return "*" + f.base.name
+	case kindSlice, kindBytes, kindStructs:
+		return "[]" + f.base.name
+	case kindArray:
+		return "[" + f.length + "]" + f.base.name
+	case kindFormat:
+		return f.base.name
+	default:
+		return f.base.name
+	}
+}
+
+// key returns the name of the variable for the lookup key of the field in the generated code.
+func (f field) key() string {
+	return "k" + f.name
+}
+
+// convertCall returns the conversion of a parsed value to the type of the field.
+func (f field) convertCall(val string) string {
+	if f.base.convert != "" {
+		val = fmt.Sprintf(f.base.convert, val)
+	}
+
+	if f.base.named != "" {
+		val = fmt.Sprintf("%s(%s)", f.base.named, val)
+	}
+
+	return val
+}
+
+// parseCall returns the parsing call for a string value.
+func (f field) parseCall(val string) string {
+	if f.base.layout {
+		return fmt.Sprintf(f.base.parse, val, strconv.Quote(reflect.StructTag(f.tag).Get("layout")))
+	}
+
+	return fmt.Sprintf(f.base.parse, val)
+}
+
+// generator generates a loader function for a struct type.
+type generator struct {
+	pkg      string
+	typeName string
+	funcName string
+	fields   []field
+	imports  map[string]bool
+	structs  map[string]bool     // the struct types declared in the package
+	named    map[string]baseType // the named types declared in the package using basic types (i.e. type Level int)
+}
+
+// generate parses the Go files in a directory and generates the loader function for a struct type.
+func generate(dir, typeName, funcName, output string) ([]byte, error) {
+	g := &generator{
+		typeName: typeName,
+		funcName: funcName,
+		imports:  map[string]bool{},
+		structs:  map[string]bool{},
+		named:    map[string]baseType{},
+	}
+
+	if g.funcName == "" {
+		g.funcName = "Load" + typeName
+	}
+
+	if err := g.parse(dir, output); err != nil {
+		return nil, err
+	}
+
+	return g.render()
+}
+
+// parse finds the struct type in the Go files of a directory and collects its fields.
+func (g *generator) parse(dir, output string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	files := []*ast.File{}
+	underlying := map[string]string{}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == filepath.Base(output) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, file)
+		for name := range structTypes(file) {
+			g.structs[name] = true
+		}
+		for name, typ := range namedTypes(file) {
+			underlying[name] = typ
+		}
+	}
+
+	for _, file := range files {
+		spec := findType(file, g.typeName)
+		if spec == nil {
+			continue
+		}
+
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return fmt.Errorf("%s is not a struct type", g.typeName)
+		}
+
+		g.pkg = file.Name.Name
+		g.resolveNamed(underlying)
+		return g.collect(file, st)
+	}
+
+	return fmt.Errorf("type %s not found in %s", g.typeName, dir)
+}
+
+// structTypes returns the names of struct types declared in a file.
+func structTypes(file *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, decl := range file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if _, ok := ts.Type.(*ast.StructType); ok {
+					names[ts.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// namedTypes returns the named types declared in a file using identifiers (i.e. type Level int) with the names of their underlying types.
+// Aliases are not included, since they are the same as the types they refer to.
+func namedTypes(file *ast.File) map[string]string {
+	names := map[string]string{}
+	for _, decl := range file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if id, ok := ts.Type.(*ast.Ident); ok && !ts.Assign.IsValid() {
+					names[ts.Name.Name] = id.Name
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// resolveNamed works out the base types for the named types declared in the package using basic types.
+// Named types can be declared using other named types (i.e. type Level Severity).
+// Pick reads the values of these types the same as their basic types, so they are parsed the same.
+func (g *generator) resolveNamed(underlying map[string]string) {
+	for name, typ := range underlying {
+		for i := 0; i < len(underlying) && underlying[typ] != ""; i++ {
+			typ = underlying[typ]
+		}
+
+		// Only basic types are keyed without package paths in baseTypes
+		base, ok := baseTypes[typ]
+		if !ok || strings.Contains(typ, ".") {
+			continue
+		}
+
+		base.name = g.pkg + "." + name
+		base.named = name
+		g.named[name] = base
+	}
+}
+
+func findType(file *ast.File, name string) *ast.TypeSpec {
+	for _, decl := range file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+			for _, spec := range gd.Specs {
+				if ts := spec.(*ast.TypeSpec); ts.Name.Name == name {
+					return ts
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// collect collects the exported fields of a struct that their types are supported.
+func (g *generator) collect(file *ast.File, st *ast.StructType) error {
+	// Map the names of imported packages to their paths
+	imports := map[string]string{}
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = path
+	}
+
+	for _, f := range st.Fields.List {
+		var tag string
+		if f.Tag != nil {
+			tag, _ = strconv.Unquote(f.Tag.Value)
+		}
+
+		// Fields can be opted out using konfig tag the same as Pick
+		if reflect.StructTag(tag).Get("konfig") == "-" {
+			continue
+		}
+
+		kind, base, typeName, err := resolveType(f.Type, imports, g.named)
+
+		var length string
+		if at, ok := f.Type.(*ast.ArrayType); ok && at.Len != nil {
+			length = exprString(at.Len)
+		}
+
+		// Lists of structs declared in the same package (i.e. []Upstream) are read field by field
+		if at, ok := f.Type.(*ast.ArrayType); ok && at.Len == nil {
+			if id, ok := at.Elt.(*ast.Ident); ok && g.structs[id.Name] {
+				kind, base, err = kindStructs, baseType{name: g.pkg + "." + id.Name}, nil
+			}
+		}
+
+		// Fields of any type can be read using format tag (i.e. maps and nested structs)
+		switch format := reflect.StructTag(tag).Get("format"); format {
+		case "":
+		case "json", "yaml":
+			kind, base, err = kindFormat, baseType{name: exprString(f.Type)}, nil
+		default:
+			err = fmt.Errorf("unknown format: %s", format)
+		}
+
+		// Pick reads pointers to and lists of named types declared using basic types only using format tag (i.e. Level but not []Level)
+		if err == nil && base.named != "" && kind != kindValue {
+			err = fmt.Errorf("unsupported type %s", exprString(f.Type))
+		}
+
+		// Embedded fields are named after their types
+		names := []string{}
+		for _, id := range f.Names {
+			names = append(names, id.Name)
+		}
+		if len(f.Names) == 0 {
+			if err != nil {
+				// Embedded fields with unsupported types (i.e. sync.Mutex) are skipped
+				continue
+			}
+			names = append(names, typeName)
+		}
+
+		for _, name := range names {
+			if !ast.IsExported(name) {
+				continue
+			}
+
+			if errors.Is(err, errSkip) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("field %s: %s", name, err)
+			}
+
+			// Lists of some types (i.e. TLS types) are skipped the same as Pick
+			if (kind == kindSlice || kind == kindArray) && base.noSlice {
+				continue
+			}
+
+			// A []byte field is read as raw bytes unless a list separator is explicitly specified for it
+			if kind == kindSlice && base.name == "uint8" && reflect.StructTag(tag).Get("sep") == "" {
+				kind = kindBytes
+			}
+
+			if base.imp != "" && kind != kindBytes {
+				g.imports[base.imp] = true
+			}
+			if kind == kindFormat {
+				for _, imp := range typeImports(f.Type, imports) {
+					g.imports[imp] = true
+				}
+			}
+			if kind == kindSlice || kind == kindArray {
+				g.imports["strings"] = true
+				if base.typeImp != "" {
+					g.imports[base.typeImp] = true
+				}
+			}
+			if kind == kindArray {
+				// Arrays with a wrong number of values are reported using fmt.Errorf
+				g.imports["fmt"] = true
+			}
+
+			g.fields = append(g.fields, field{
+				name:   name,
+				tag:    tag,
+				kind:   kind,
+				base:   base,
+				length: length,
+			})
+		}
+	}
+
+	return nil
+}
+
+// errSkip is returned for types that are never read (i.e. channels and maps).
+var errSkip = errors.New("skipped type")
+
+// resolveType returns the kind and the base type of a field type.
+// named are the named types declared in the package using basic types.
+// The third returned value is the name of the type used for embedded fields.
+func resolveType(expr ast.Expr, imports map[string]string, named map[string]baseType) (fieldKind, baseType, string, error) {
+	kind := kindValue
+
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		// Some types are only used through pointers (i.e. *time.Location)
+		if key, _ := typeKey(e, imports); baseTypes[key].name != "" {
+			break
+		}
+		kind, expr = kindPointer, e.X
+	case *ast.ArrayType:
+		if e.Len == nil {
+			kind, expr = kindSlice, e.Elt
+		} else {
+			kind, expr = kindArray, e.Elt
+		}
+	}
+
+	switch e := expr.(type) {
+	case *ast.ArrayType:
+		// Nested arrays and slices of arrays (i.e. [2][]int) are not read
+		if e.Len != nil || kind == kindArray {
+			return 0, baseType{}, "", errSkip
+		}
+	case *ast.ChanType, *ast.MapType, *ast.FuncType, *ast.InterfaceType, *ast.StructType:
+		return 0, baseType{}, "", errSkip
+	}
+
+	key, name := typeKey(expr, imports)
+	base, ok := baseTypes[key]
+	if !ok {
+		base, ok = named[key]
+	}
+	if !ok {
+		return 0, baseType{}, name, fmt.Errorf("unsupported type %s", exprString(expr))
+	}
+
+	return kind, base, name, nil
+}
+
+// typeKey returns the key of a type expression in baseTypes (i.e. net/url.URL or *time.Location) and the name of the type.
+func typeKey(expr ast.Expr, imports map[string]string) (string, string) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name, e.Name
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			return imports[x.Name] + "." + e.Sel.Name, e.Sel.Name
+		}
+	case *ast.StarExpr:
+		if key, name := typeKey(e.X, imports); key != "" {
+			return "*" + key, name
+		}
+	}
+
+	return "", ""
+}
+
+// typeImports returns the import paths of the packages referred to by a type expression (i.e. time for map[string]time.Duration).
+func typeImports(expr ast.Expr, imports map[string]string) []string {
+	paths := []string{}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && imports[x.Name] != "" && imports[x.Name] != konfigImport {
+				paths = append(paths, imports[x.Name])
+			}
+		}
+		return true
+	})
+
+	return paths
+}
+
+// exprString returns the source representation of a type expression.
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return fmt.Sprintf("%T", expr)
+	}
+
+	return buf.String()
+}
+
+// render writes the Go source code for the loader function.
+func (g *generator) render() ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by konfig-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg)
+
+	imports := []string{}
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+
+	fmt.Fprintf(&buf, "import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&buf, "%q\n", imp)
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&buf, "\n")
+	}
+	fmt.Fprintf(&buf, "%q\n)\n\n", konfigImport)
+
+	fmt.Fprintf(&buf, "// %s reads values for exported fields of %s from either command-line flags, environment variables, or configuration files.\n", g.funcName, g.typeName)
+	fmt.Fprintf(&buf, "// It follows the same rules as konfig.Pick (including strict mode), but values are parsed and set without reflection.\n")
+	fmt.Fprintf(&buf, "// Fields with invalid values keep their current values and an error is returned for them the same as konfig.Pick.\n")
+	fmt.Fprintf(&buf, "func %s(config *%s, opts ...konfig.Option) error {\n", g.funcName, g.typeName)
+	fmt.Fprintf(&buf, "l := konfig.NewLookup(opts...)\n\n")
+
+	// The names for every field are worked out once when the field is registered
+	for _, f := range g.fields {
+		fmt.Fprintf(&buf, "%s := l.RegisterFlag(%q, %s, %q, config.%s)\n", f.key(), f.name, quoteTag(f.tag), f.dataType(), f.name)
+	}
+
+	// Invalid tags, unsupported fields, and unknown names are checked after all flags are defined the same as Pick
+	fmt.Fprintf(&buf, "\nif err := l.Check(config); err != nil {\n")
+	fmt.Fprintf(&buf, "return err\n")
+	fmt.Fprintf(&buf, "}\n")
+
+	for _, f := range g.fields {
+		buf.WriteString("\n")
+		g.renderField(&buf, f)
+	}
+
+	fmt.Fprintf(&buf, "\nreturn l.Err()\n")
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format the generated code: %s", err)
+	}
+
+	return src, nil
+}
+
+// quoteTag returns a Go string literal for a struct tag.
+func quoteTag(tag string) string {
+	if strconv.CanBackquote(tag) {
+		return "`" + tag + "`"
+	}
+	return strconv.Quote(tag)
+}
+
+// renderField writes the Go source code for reading the value of a field.
+// Errors for values that cannot be parsed are reported to the lookup, so they are returned the same as Pick.
+func (g *generator) renderField(buf *bytes.Buffer, f field) {
+	target := "config." + f.name
+	key := f.key()
+
+	if f.kind == kindStructs {
+		fmt.Fprintf(buf, "l.Structs(%s, &%s)\n", key, target)
+		return
+	}
+
+	if f.kind == kindFormat {
+		format := reflect.StructTag(f.tag).Get("format")
+		fmt.Fprintf(buf, "if val, _ := l.Value(%s); val != \"\" {\n", key)
+		fmt.Fprintf(buf, "var v %s\n", f.base.name)
+		fmt.Fprintf(buf, "if err := konfig.Unmarshal(%q, val, &v); err == nil {\n", format)
+		fmt.Fprintf(buf, "%s = v\n", target)
+		fmt.Fprintf(buf, "} else {\n")
+		fmt.Fprintf(buf, "l.Invalid(%s, err)\n", key)
+		fmt.Fprintf(buf, "}\n")
+		fmt.Fprintf(buf, "}\n")
+		return
+	}
+
+	if f.kind == kindBytes {
+		fmt.Fprintf(buf, "if val := l.Bytes(%s); val != nil {\n", key)
+		fmt.Fprintf(buf, "%s = val\n", target)
+		fmt.Fprintf(buf, "}\n")
+		return
+	}
+
+	sepVar := "_"
+	if f.kind == kindSlice || f.kind == kindArray {
+		sepVar = "sep"
+	}
+
+	fmt.Fprintf(buf, "if val, %s := l.Value(%s); val != \"\" {\n", sepVar, key)
+
+	switch {
+	case f.base.parse == "" && f.kind == kindValue:
+		fmt.Fprintf(buf, "%s = %s\n", target, f.convertCall("val"))
+
+	case f.base.parse == "" && f.kind == kindPointer:
+		fmt.Fprintf(buf, "v := val\n")
+		fmt.Fprintf(buf, "%s = &v\n", target)
+
+	case f.base.parse == "" && f.kind == kindSlice:
+		fmt.Fprintf(buf, "%s = strings.Split(val, sep)\n", target)
+
+	case f.base.parse == "" && f.kind == kindArray:
+		fmt.Fprintf(buf, "if vals := strings.Split(val, sep); len(vals) != len(%s) {\n", target)
+		fmt.Fprintf(buf, "l.Invalid(%s, fmt.Errorf(\"invalid number of values: expected %%d, got %%d\", len(%s), len(vals)))\n", key, target)
+		fmt.Fprintf(buf, "} else {\n")
+		fmt.Fprintf(buf, "copy(%s[:], vals)\n", target)
+		fmt.Fprintf(buf, "}\n")
+
+	case f.kind == kindValue:
+		fmt.Fprintf(buf, "if v, err := %s; err == nil {\n", f.parseCall("val"))
+		fmt.Fprintf(buf, "%s = %s\n", target, f.convertCall("v"))
+		fmt.Fprintf(buf, "} else {\n")
+		fmt.Fprintf(buf, "l.Invalid(%s, err)\n", key)
+		fmt.Fprintf(buf, "}\n")
+
+	case f.kind == kindPointer:
+		fmt.Fprintf(buf, "if v, err := %s; err == nil {\n", f.parseCall("val"))
+		if f.base.ptrResult {
+			fmt.Fprintf(buf, "%s = v\n", target)
+		} else {
+			fmt.Fprintf(buf, "p := %s\n", f.convertCall("v"))
+			fmt.Fprintf(buf, "%s = &p\n", target)
+		}
+		fmt.Fprintf(buf, "} else {\n")
+		fmt.Fprintf(buf, "l.Invalid(%s, err)\n", key)
+		fmt.Fprintf(buf, "}\n")
+
+	case f.kind == kindSlice:
+		fmt.Fprintf(buf, "vals := []%s{}\n", f.base.name)
+		fmt.Fprintf(buf, "for _, s := range strings.Split(val, sep) {\n")
+		fmt.Fprintf(buf, "v, err := %s\n", f.parseCall("s"))
+		fmt.Fprintf(buf, "if err != nil {\n")
+		fmt.Fprintf(buf, "l.Invalid(%s, err)\n", key)
+		fmt.Fprintf(buf, "vals = nil\n")
+		fmt.Fprintf(buf, "break\n")
+		fmt.Fprintf(buf, "}\n")
+		fmt.Fprintf(buf, "vals = append(vals, %s)\n", f.convertCall("v"))
+		fmt.Fprintf(buf, "}\n")
+		fmt.Fprintf(buf, "if vals != nil {\n")
+		fmt.Fprintf(buf, "%s = vals\n", target)
+		fmt.Fprintf(buf, "}\n")
+
+	case f.kind == kindArray:
+		// The array is set only if the number of values is the same as its length and all values are valid
+		fmt.Fprintf(buf, "if parts := strings.Split(val, sep); len(parts) != len(%s) {\n", target)
+		fmt.Fprintf(buf, "l.Invalid(%s, fmt.Errorf(\"invalid number of values: expected %%d, got %%d\", len(%s), len(parts)))\n", key, target)
+		fmt.Fprintf(buf, "} else {\n")
+		fmt.Fprintf(buf, "var vals %s\n", f.dataType())
+		fmt.Fprintf(buf, "n := 0\n")
+		fmt.Fprintf(buf, "for i, s := range parts {\n")
+		fmt.Fprintf(buf, "v, err := %s\n", f.parseCall("s"))
+		fmt.Fprintf(buf, "if err != nil {\n")
+		fmt.Fprintf(buf, "l.Invalid(%s, err)\n", key)
+		fmt.Fprintf(buf, "break\n")
+		fmt.Fprintf(buf, "}\n")
+		fmt.Fprintf(buf, "vals[i] = %s\n", f.convertCall("v"))
+		fmt.Fprintf(buf, "n++\n")
+		fmt.Fprintf(buf, "}\n")
+		fmt.Fprintf(buf, "if n == len(vals) {\n")
+		fmt.Fprintf(buf, "%s = vals\n", target)
+		fmt.Fprintf(buf, "}\n")
+		fmt.Fprintf(buf, "}\n")
+	}
+
+	fmt.Fprintf(buf, "}\n")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("Golden", func(t *testing.T) {
+		// The golden file is the generated code for the fixture package.
+		// After changing the generator, run go generate ./... to update it.
+		golden, err := ioutil.ReadFile("internal/fixture/config_konfig.go")
+		assert.NoError(t, err)
+
+		src, err := generate("internal/fixture", "Config", "", "internal/fixture/config_konfig.go")
+		assert.NoError(t, err)
+		assert.Equal(t, string(golden), string(src))
+	})
+
+	tests := []struct {
+		name          string
+		typeName      string
+		expectedError error
+	}{
+		{"NotFound", "Config", errors.New("type Config not found in testdata")},
+		{"NotStruct", "NotStruct", errors.New("NotStruct is not a struct type")},
+		{"UnsupportedType", "Unsupported", errors.New("field Levels: unsupported type []Level")},
+		{"UnknownFormat", "UnknownFormat", errors.New("field Routes: unknown format: xml")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src, err := generate("testdata", tc.typeName, "", "testdata/output.go")
+			assert.Nil(t, src)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+
+	t.Run("SkippedTypes", func(t *testing.T) {
+		src, err := generate("testdata", "Skipped", "LoadSkipped", "testdata/output.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(src), "func LoadSkipped(config *Skipped, opts ...konfig.Option) error {")
+		assert.Contains(t, string(src), "kValue := l.RegisterFlag(\"Value\", ``, \"string\", config.Value)")
+		assert.NotContains(t, string(src), "config.Map")
+		assert.NotContains(t, string(src), "config.Func")
+		assert.NotContains(t, string(src), "config.Array")
+		assert.NotContains(t, string(src), "config.Arrays")
+		assert.NotContains(t, string(src), "config.Level")
+	})
+
+	t.Run("NamedTypes", func(t *testing.T) {
+		src, err := generate("testdata", "Named", "", "testdata/output.go")
+		assert.NoError(t, err)
+		assert.Contains(t, string(src), "kLevel := l.RegisterFlag(\"Level\", ``, \"testdata.Level\", config.Level)")
+		assert.Contains(t, string(src), "config.Level = Level(val)")
+		assert.Contains(t, string(src), "config.Priority = Priority(int(v))")
+	})
+}
@@ -0,0 +1,156 @@
+// Package fixture has a configuration struct for testing the code generated by konfig-gen.
+package fixture
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/moorara/konfig"
+)
+
+//go:generate go run ../.. -type Config
+
+// Config has a field for every type supported by konfig-gen.
+type Config struct {
+	sync.Mutex
+	unexported      string
+	Channel         chan int
+	SkipFlag        string `flag:"-"`
+	SkipFlagEnv     string `flag:"-" env:"-"`
+	SkipFlagEnvFile string `flag:"-" env:"-" fileenv:"-"`
+	Custom          string `flag:"fixture.custom" env:"FIXTURE_CUSTOM" fileenv:"FIXTURE_CUSTOM_PATH"`
+	Encoded         string `encoding:"base64"`
+	String          string
+	Bool            bool
+	Float32         float32
+	Float64         float64
+	Int             int
+	Int8            int8
+	Int16           int16
+	Int32           int32
+	Int64           int64
+	Duration        time.Duration
+	Uint            uint
+	Uint8           uint8
+	Uint16          uint16
+	Uint32          uint32
+	Uint64          uint64
+	URL             url.URL
+	Regexp          regexp.Regexp
+	StringPtr       *string
+	BoolPtr         *bool
+	Float32Ptr      *float32
+	Float64Ptr      *float64
+	IntPtr          *int
+	Int8Ptr         *int8
+	Int16Ptr        *int16
+	Int32Ptr        *int32
+	Int64Ptr        *int64
+	DurationPtr     *time.Duration
+	UintPtr         *uint
+	Uint8Ptr        *uint8
+	Uint16Ptr       *uint16
+	Uint32Ptr       *uint32
+	Uint64Ptr       *uint64
+	URLPtr          *url.URL
+	RegexpPtr       *regexp.Regexp
+	StringSlice     []string
+	BoolSlice       []bool
+	Float32Slice    []float32
+	Float64Slice    []float64
+	IntSlice        []int
+	Int8Slice       []int8
+	Int16Slice      []int16
+	Int32Slice      []int32
+	Int64Slice      []int64
+	DurationSlice   []time.Duration
+	UintSlice       []uint
+	Uint8Slice      []uint8 `sep:"|"`
+	Uint16Slice     []uint16
+	Uint32Slice     []uint32
+	Uint64Slice     []uint64
+	URLSlice        []url.URL
+	RegexpSlice     []regexp.Regexp
+	Bytes           []byte
+	Certificate     tls.Certificate
+	CertificatePtr  *tls.Certificate
+	CertPool        x509.CertPool
+	CertPoolPtr     *x509.CertPool
+	CertificateList []tls.Certificate
+	IP              net.IP
+	IPPtr           *net.IP
+	IPSlice         []net.IP
+	IPNet           net.IPNet
+	IPNetPtr        *net.IPNet
+	IPNetSlice      []net.IPNet
+	Addr            netip.Addr
+	AddrPtr         *netip.Addr
+	AddrSlice       []netip.Addr
+	Prefix          netip.Prefix
+	PrefixPtr       *netip.Prefix
+	PrefixSlice     []netip.Prefix
+	AddrPort        netip.AddrPort
+	AddrPortPtr     *netip.AddrPort
+	AddrPortSlice   []netip.AddrPort
+	Time            time.Time
+	TimePtr         *time.Time
+	TimeSlice       []time.Time
+	Date            time.Time  `layout:"DateOnly"`
+	Kitchen         *time.Time `layout:"3:04PM"`
+	Location        *time.Location
+	LocationSlice   []*time.Location
+	TimeOfDay       konfig.TimeOfDay
+	TimeOfDayPtr    *konfig.TimeOfDay
+	TimeOfDaySlice  []konfig.TimeOfDay
+	ByteSize        konfig.ByteSize
+	ByteSizePtr     *konfig.ByteSize
+	ByteSizeSlice   []konfig.ByteSize
+	Quantity        konfig.Quantity
+	QuantityPtr     *konfig.Quantity
+	QuantitySlice   []konfig.Quantity
+	CacheSize       int64  `unit:"bytes"`
+	UploadLimits    []uint `unit:"bytes"`
+	Upstreams       []Upstream
+	Backends        []Upstream               `env:"BACKENDS_LIST" fileenv:"BACKENDS_LIST_FILE"`
+	Routes          map[string]time.Duration `format:"json"`
+	Primary         *Upstream                `format:"yaml"`
+	Matrix          [][]int                  `format:"json"`
+	Complex64       complex64
+	Complex128      complex128
+	Complex64Ptr    *complex64
+	Complex128Slice []complex128
+	Weights         [3]float64
+	Pair            [2]string
+	Timeouts        [2]time.Duration
+	Gateways        [2]net.IP
+	Window          [2]konfig.TimeOfDay
+	Octets          [4]uint8 `sep:"."`
+	Level           Level
+	Severity        Severity
+	Mode            Mode
+	Ignored         string `konfig:"-"`
+}
+
+// Level is a named type declared using a basic type.
+type Level int
+
+// Severity is a named type declared using another named type.
+type Severity Level
+
+// Mode is a named string type.
+type Mode string
+
+// Upstream is the type for the elements of lists of structs.
+type Upstream struct {
+	Host   string
+	Port   int
+	Weight float64 `flag:"-"`
+	Tags   []string
+	Limit  konfig.ByteSize
+}
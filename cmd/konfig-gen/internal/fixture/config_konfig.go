@@ -0,0 +1,1303 @@
+// Code generated by konfig-gen. DO NOT EDIT.
+
+package fixture
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moorara/konfig"
+)
+
+// LoadConfig reads values for exported fields of Config from either command-line flags, environment variables, or configuration files.
+// It follows the same rules as konfig.Pick (including strict mode), but values are parsed and set without reflection.
+// Fields with invalid values keep their current values and an error is returned for them the same as konfig.Pick.
+func LoadConfig(config *Config, opts ...konfig.Option) error {
+	l := konfig.NewLookup(opts...)
+
+	kSkipFlag := l.RegisterFlag("SkipFlag", `flag:"-"`, "string", config.SkipFlag)
+	kSkipFlagEnv := l.RegisterFlag("SkipFlagEnv", `flag:"-" env:"-"`, "string", config.SkipFlagEnv)
+	kSkipFlagEnvFile := l.RegisterFlag("SkipFlagEnvFile", `flag:"-" env:"-" fileenv:"-"`, "string", config.SkipFlagEnvFile)
+	kCustom := l.RegisterFlag("Custom", `flag:"fixture.custom" env:"FIXTURE_CUSTOM" fileenv:"FIXTURE_CUSTOM_PATH"`, "string", config.Custom)
+	kEncoded := l.RegisterFlag("Encoded", `encoding:"base64"`, "string", config.Encoded)
+	kString := l.RegisterFlag("String", ``, "string", config.String)
+	kBool := l.RegisterFlag("Bool", ``, "bool", config.Bool)
+	kFloat32 := l.RegisterFlag("Float32", ``, "float32", config.Float32)
+	kFloat64 := l.RegisterFlag("Float64", ``, "float64", config.Float64)
+	kInt := l.RegisterFlag("Int", ``, "int", config.Int)
+	kInt8 := l.RegisterFlag("Int8", ``, "int8", config.Int8)
+	kInt16 := l.RegisterFlag("Int16", ``, "int16", config.Int16)
+	kInt32 := l.RegisterFlag("Int32", ``, "int32", config.Int32)
+	kInt64 := l.RegisterFlag("Int64", ``, "int64", config.Int64)
+	kDuration := l.RegisterFlag("Duration", ``, "time.Duration", config.Duration)
+	kUint := l.RegisterFlag("Uint", ``, "uint", config.Uint)
+	kUint8 := l.RegisterFlag("Uint8", ``, "uint8", config.Uint8)
+	kUint16 := l.RegisterFlag("Uint16", ``, "uint16", config.Uint16)
+	kUint32 := l.RegisterFlag("Uint32", ``, "uint32", config.Uint32)
+	kUint64 := l.RegisterFlag("Uint64", ``, "uint64", config.Uint64)
+	kURL := l.RegisterFlag("URL", ``, "url.URL", config.URL)
+	kRegexp := l.RegisterFlag("Regexp", ``, "regexp.Regexp", config.Regexp)
+	kStringPtr := l.RegisterFlag("StringPtr", ``, "*string", config.StringPtr)
+	kBoolPtr := l.RegisterFlag("BoolPtr", ``, "*bool", config.BoolPtr)
+	kFloat32Ptr := l.RegisterFlag("Float32Ptr", ``, "*float32", config.Float32Ptr)
+	kFloat64Ptr := l.RegisterFlag("Float64Ptr", ``, "*float64", config.Float64Ptr)
+	kIntPtr := l.RegisterFlag("IntPtr", ``, "*int", config.IntPtr)
+	kInt8Ptr := l.RegisterFlag("Int8Ptr", ``, "*int8", config.Int8Ptr)
+	kInt16Ptr := l.RegisterFlag("Int16Ptr", ``, "*int16", config.Int16Ptr)
+	kInt32Ptr := l.RegisterFlag("Int32Ptr", ``, "*int32", config.Int32Ptr)
+	kInt64Ptr := l.RegisterFlag("Int64Ptr", ``, "*int64", config.Int64Ptr)
+	kDurationPtr := l.RegisterFlag("DurationPtr", ``, "*time.Duration", config.DurationPtr)
+	kUintPtr := l.RegisterFlag("UintPtr", ``, "*uint", config.UintPtr)
+	kUint8Ptr := l.RegisterFlag("Uint8Ptr", ``, "*uint8", config.Uint8Ptr)
+	kUint16Ptr := l.RegisterFlag("Uint16Ptr", ``, "*uint16", config.Uint16Ptr)
+	kUint32Ptr := l.RegisterFlag("Uint32Ptr", ``, "*uint32", config.Uint32Ptr)
+	kUint64Ptr := l.RegisterFlag("Uint64Ptr", ``, "*uint64", config.Uint64Ptr)
+	kURLPtr := l.RegisterFlag("URLPtr", ``, "*url.URL", config.URLPtr)
+	kRegexpPtr := l.RegisterFlag("RegexpPtr", ``, "*regexp.Regexp", config.RegexpPtr)
+	kStringSlice := l.RegisterFlag("StringSlice", ``, "[]string", config.StringSlice)
+	kBoolSlice := l.RegisterFlag("BoolSlice", ``, "[]bool", config.BoolSlice)
+	kFloat32Slice := l.RegisterFlag("Float32Slice", ``, "[]float32", config.Float32Slice)
+	kFloat64Slice := l.RegisterFlag("Float64Slice", ``, "[]float64", config.Float64Slice)
+	kIntSlice := l.RegisterFlag("IntSlice", ``, "[]int", config.IntSlice)
+	kInt8Slice := l.RegisterFlag("Int8Slice", ``, "[]int8", config.Int8Slice)
+	kInt16Slice := l.RegisterFlag("Int16Slice", ``, "[]int16", config.Int16Slice)
+	kInt32Slice := l.RegisterFlag("Int32Slice", ``, "[]int32", config.Int32Slice)
+	kInt64Slice := l.RegisterFlag("Int64Slice", ``, "[]int64", config.Int64Slice)
+	kDurationSlice := l.RegisterFlag("DurationSlice", ``, "[]time.Duration", config.DurationSlice)
+	kUintSlice := l.RegisterFlag("UintSlice", ``, "[]uint", config.UintSlice)
+	kUint8Slice := l.RegisterFlag("Uint8Slice", `sep:"|"`, "[]uint8", config.Uint8Slice)
+	kUint16Slice := l.RegisterFlag("Uint16Slice", ``, "[]uint16", config.Uint16Slice)
+	kUint32Slice := l.RegisterFlag("Uint32Slice", ``, "[]uint32", config.Uint32Slice)
+	kUint64Slice := l.RegisterFlag("Uint64Slice", ``, "[]uint64", config.Uint64Slice)
+	kURLSlice := l.RegisterFlag("URLSlice", ``, "[]url.URL", config.URLSlice)
+	kRegexpSlice := l.RegisterFlag("RegexpSlice", ``, "[]regexp.Regexp", config.RegexpSlice)
+	kBytes := l.RegisterFlag("Bytes", ``, "[]uint8", config.Bytes)
+	kCertificate := l.RegisterFlag("Certificate", ``, "tls.Certificate", config.Certificate)
+	kCertificatePtr := l.RegisterFlag("CertificatePtr", ``, "*tls.Certificate", config.CertificatePtr)
+	kCertPool := l.RegisterFlag("CertPool", ``, "x509.CertPool", config.CertPool)
+	kCertPoolPtr := l.RegisterFlag("CertPoolPtr", ``, "*x509.CertPool", config.CertPoolPtr)
+	kIP := l.RegisterFlag("IP", ``, "net.IP", config.IP)
+	kIPPtr := l.RegisterFlag("IPPtr", ``, "*net.IP", config.IPPtr)
+	kIPSlice := l.RegisterFlag("IPSlice", ``, "[]net.IP", config.IPSlice)
+	kIPNet := l.RegisterFlag("IPNet", ``, "net.IPNet", config.IPNet)
+	kIPNetPtr := l.RegisterFlag("IPNetPtr", ``, "*net.IPNet", config.IPNetPtr)
+	kIPNetSlice := l.RegisterFlag("IPNetSlice", ``, "[]net.IPNet", config.IPNetSlice)
+	kAddr := l.RegisterFlag("Addr", ``, "netip.Addr", config.Addr)
+	kAddrPtr := l.RegisterFlag("AddrPtr", ``, "*netip.Addr", config.AddrPtr)
+	kAddrSlice := l.RegisterFlag("AddrSlice", ``, "[]netip.Addr", config.AddrSlice)
+	kPrefix := l.RegisterFlag("Prefix", ``, "netip.Prefix", config.Prefix)
+	kPrefixPtr := l.RegisterFlag("PrefixPtr", ``, "*netip.Prefix", config.PrefixPtr)
+	kPrefixSlice := l.RegisterFlag("PrefixSlice", ``, "[]netip.Prefix", config.PrefixSlice)
+	kAddrPort := l.RegisterFlag("AddrPort", ``, "netip.AddrPort", config.AddrPort)
+	kAddrPortPtr := l.RegisterFlag("AddrPortPtr", ``, "*netip.AddrPort", config.AddrPortPtr)
+	kAddrPortSlice := l.RegisterFlag("AddrPortSlice", ``, "[]netip.AddrPort", config.AddrPortSlice)
+	kTime := l.RegisterFlag("Time", ``, "time.Time", config.Time)
+	kTimePtr := l.RegisterFlag("TimePtr", ``, "*time.Time", config.TimePtr)
+	kTimeSlice := l.RegisterFlag("TimeSlice", ``, "[]time.Time", config.TimeSlice)
+	kDate := l.RegisterFlag("Date", `layout:"DateOnly"`, "time.Time", config.Date)
+	kKitchen := l.RegisterFlag("Kitchen", `layout:"3:04PM"`, "*time.Time", config.Kitchen)
+	kLocation := l.RegisterFlag("Location", ``, "*time.Location", config.Location)
+	kLocationSlice := l.RegisterFlag("LocationSlice", ``, "[]*time.Location", config.LocationSlice)
+	kTimeOfDay := l.RegisterFlag("TimeOfDay", ``, "konfig.TimeOfDay", config.TimeOfDay)
+	kTimeOfDayPtr := l.RegisterFlag("TimeOfDayPtr", ``, "*konfig.TimeOfDay", config.TimeOfDayPtr)
+	kTimeOfDaySlice := l.RegisterFlag("TimeOfDaySlice", ``, "[]konfig.TimeOfDay", config.TimeOfDaySlice)
+	kByteSize := l.RegisterFlag("ByteSize", ``, "konfig.ByteSize", config.ByteSize)
+	kByteSizePtr := l.RegisterFlag("ByteSizePtr", ``, "*konfig.ByteSize", config.ByteSizePtr)
+	kByteSizeSlice := l.RegisterFlag("ByteSizeSlice", ``, "[]konfig.ByteSize", config.ByteSizeSlice)
+	kQuantity := l.RegisterFlag("Quantity", ``, "konfig.Quantity", config.Quantity)
+	kQuantityPtr := l.RegisterFlag("QuantityPtr", ``, "*konfig.Quantity", config.QuantityPtr)
+	kQuantitySlice := l.RegisterFlag("QuantitySlice", ``, "[]konfig.Quantity", config.QuantitySlice)
+	kCacheSize := l.RegisterFlag("CacheSize", `unit:"bytes"`, "int64", config.CacheSize)
+	kUploadLimits := l.RegisterFlag("UploadLimits", `unit:"bytes"`, "[]uint", config.UploadLimits)
+	kUpstreams := l.RegisterFlag("Upstreams", ``, "[]fixture.Upstream", config.Upstreams)
+	kBackends := l.RegisterFlag("Backends", `env:"BACKENDS_LIST" fileenv:"BACKENDS_LIST_FILE"`, "[]fixture.Upstream", config.Backends)
+	kRoutes := l.RegisterFlag("Routes", `format:"json"`, "map[string]time.Duration", config.Routes)
+	kPrimary := l.RegisterFlag("Primary", `format:"yaml"`, "*Upstream", config.Primary)
+	kMatrix := l.RegisterFlag("Matrix", `format:"json"`, "[][]int", config.Matrix)
+	kComplex64 := l.RegisterFlag("Complex64", ``, "complex64", config.Complex64)
+	kComplex128 := l.RegisterFlag("Complex128", ``, "complex128", config.Complex128)
+	kComplex64Ptr := l.RegisterFlag("Complex64Ptr", ``, "*complex64", config.Complex64Ptr)
+	kComplex128Slice := l.RegisterFlag("Complex128Slice", ``, "[]complex128", config.Complex128Slice)
+	kWeights := l.RegisterFlag("Weights", ``, "[3]float64", config.Weights)
+	kPair := l.RegisterFlag("Pair", ``, "[2]string", config.Pair)
+	kTimeouts := l.RegisterFlag("Timeouts", ``, "[2]time.Duration", config.Timeouts)
+	kGateways := l.RegisterFlag("Gateways", ``, "[2]net.IP", config.Gateways)
+	kWindow := l.RegisterFlag("Window", ``, "[2]konfig.TimeOfDay", config.Window)
+	kOctets := l.RegisterFlag("Octets", `sep:"."`, "[4]uint8", config.Octets)
+	kLevel := l.RegisterFlag("Level", ``, "fixture.Level", config.Level)
+	kSeverity := l.RegisterFlag("Severity", ``, "fixture.Severity", config.Severity)
+	kMode := l.RegisterFlag("Mode", ``, "fixture.Mode", config.Mode)
+
+	if err := l.Check(config); err != nil {
+		return err
+	}
+
+	if val, _ := l.Value(kSkipFlag); val != "" {
+		config.SkipFlag = val
+	}
+
+	if val, _ := l.Value(kSkipFlagEnv); val != "" {
+		config.SkipFlagEnv = val
+	}
+
+	if val, _ := l.Value(kSkipFlagEnvFile); val != "" {
+		config.SkipFlagEnvFile = val
+	}
+
+	if val, _ := l.Value(kCustom); val != "" {
+		config.Custom = val
+	}
+
+	if val, _ := l.Value(kEncoded); val != "" {
+		config.Encoded = val
+	}
+
+	if val, _ := l.Value(kString); val != "" {
+		config.String = val
+	}
+
+	if val, _ := l.Value(kBool); val != "" {
+		if v, err := strconv.ParseBool(val); err == nil {
+			config.Bool = v
+		} else {
+			l.Invalid(kBool, err)
+		}
+	}
+
+	if val, _ := l.Value(kFloat32); val != "" {
+		if v, err := strconv.ParseFloat(val, 32); err == nil {
+			config.Float32 = float32(v)
+		} else {
+			l.Invalid(kFloat32, err)
+		}
+	}
+
+	if val, _ := l.Value(kFloat64); val != "" {
+		if v, err := strconv.ParseFloat(val, 64); err == nil {
+			config.Float64 = v
+		} else {
+			l.Invalid(kFloat64, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.Int = int(v)
+		} else {
+			l.Invalid(kInt, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt8); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 8); err == nil {
+			config.Int8 = int8(v)
+		} else {
+			l.Invalid(kInt8, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt16); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 16); err == nil {
+			config.Int16 = int16(v)
+		} else {
+			l.Invalid(kInt16, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt32); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 32); err == nil {
+			config.Int32 = int32(v)
+		} else {
+			l.Invalid(kInt32, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt64); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.Int64 = v
+		} else {
+			l.Invalid(kInt64, err)
+		}
+	}
+
+	if val, _ := l.Value(kDuration); val != "" {
+		if v, err := time.ParseDuration(val); err == nil {
+			config.Duration = v
+		} else {
+			l.Invalid(kDuration, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 64); err == nil {
+			config.Uint = uint(v)
+		} else {
+			l.Invalid(kUint, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint8); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 8); err == nil {
+			config.Uint8 = uint8(v)
+		} else {
+			l.Invalid(kUint8, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint16); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 16); err == nil {
+			config.Uint16 = uint16(v)
+		} else {
+			l.Invalid(kUint16, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint32); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 32); err == nil {
+			config.Uint32 = uint32(v)
+		} else {
+			l.Invalid(kUint32, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint64); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 64); err == nil {
+			config.Uint64 = v
+		} else {
+			l.Invalid(kUint64, err)
+		}
+	}
+
+	if val, _ := l.Value(kURL); val != "" {
+		if v, err := url.Parse(val); err == nil {
+			config.URL = *v
+		} else {
+			l.Invalid(kURL, err)
+		}
+	}
+
+	if val, _ := l.Value(kRegexp); val != "" {
+		if v, err := regexp.CompilePOSIX(val); err == nil {
+			config.Regexp = *v
+		} else {
+			l.Invalid(kRegexp, err)
+		}
+	}
+
+	if val, _ := l.Value(kStringPtr); val != "" {
+		v := val
+		config.StringPtr = &v
+	}
+
+	if val, _ := l.Value(kBoolPtr); val != "" {
+		if v, err := strconv.ParseBool(val); err == nil {
+			p := v
+			config.BoolPtr = &p
+		} else {
+			l.Invalid(kBoolPtr, err)
+		}
+	}
+
+	if val, _ := l.Value(kFloat32Ptr); val != "" {
+		if v, err := strconv.ParseFloat(val, 32); err == nil {
+			p := float32(v)
+			config.Float32Ptr = &p
+		} else {
+			l.Invalid(kFloat32Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kFloat64Ptr); val != "" {
+		if v, err := strconv.ParseFloat(val, 64); err == nil {
+			p := v
+			config.Float64Ptr = &p
+		} else {
+			l.Invalid(kFloat64Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kIntPtr); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 64); err == nil {
+			p := int(v)
+			config.IntPtr = &p
+		} else {
+			l.Invalid(kIntPtr, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt8Ptr); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 8); err == nil {
+			p := int8(v)
+			config.Int8Ptr = &p
+		} else {
+			l.Invalid(kInt8Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt16Ptr); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 16); err == nil {
+			p := int16(v)
+			config.Int16Ptr = &p
+		} else {
+			l.Invalid(kInt16Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt32Ptr); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 32); err == nil {
+			p := int32(v)
+			config.Int32Ptr = &p
+		} else {
+			l.Invalid(kInt32Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kInt64Ptr); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 64); err == nil {
+			p := v
+			config.Int64Ptr = &p
+		} else {
+			l.Invalid(kInt64Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kDurationPtr); val != "" {
+		if v, err := time.ParseDuration(val); err == nil {
+			p := v
+			config.DurationPtr = &p
+		} else {
+			l.Invalid(kDurationPtr, err)
+		}
+	}
+
+	if val, _ := l.Value(kUintPtr); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 64); err == nil {
+			p := uint(v)
+			config.UintPtr = &p
+		} else {
+			l.Invalid(kUintPtr, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint8Ptr); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 8); err == nil {
+			p := uint8(v)
+			config.Uint8Ptr = &p
+		} else {
+			l.Invalid(kUint8Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint16Ptr); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 16); err == nil {
+			p := uint16(v)
+			config.Uint16Ptr = &p
+		} else {
+			l.Invalid(kUint16Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint32Ptr); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 32); err == nil {
+			p := uint32(v)
+			config.Uint32Ptr = &p
+		} else {
+			l.Invalid(kUint32Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kUint64Ptr); val != "" {
+		if v, err := strconv.ParseUint(val, 10, 64); err == nil {
+			p := v
+			config.Uint64Ptr = &p
+		} else {
+			l.Invalid(kUint64Ptr, err)
+		}
+	}
+
+	if val, _ := l.Value(kURLPtr); val != "" {
+		if v, err := url.Parse(val); err == nil {
+			config.URLPtr = v
+		} else {
+			l.Invalid(kURLPtr, err)
+		}
+	}
+
+	if val, _ := l.Value(kRegexpPtr); val != "" {
+		if v, err := regexp.CompilePOSIX(val); err == nil {
+			config.RegexpPtr = v
+		} else {
+			l.Invalid(kRegexpPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kStringSlice); val != "" {
+		config.StringSlice = strings.Split(val, sep)
+	}
+
+	if val, sep := l.Value(kBoolSlice); val != "" {
+		vals := []bool{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseBool(s)
+			if err != nil {
+				l.Invalid(kBoolSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.BoolSlice = vals
+		}
+	}
+
+	if val, sep := l.Value(kFloat32Slice); val != "" {
+		vals := []float32{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseFloat(s, 32)
+			if err != nil {
+				l.Invalid(kFloat32Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, float32(v))
+		}
+		if vals != nil {
+			config.Float32Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kFloat64Slice); val != "" {
+		vals := []float64{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				l.Invalid(kFloat64Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.Float64Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kIntSlice); val != "" {
+		vals := []int{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				l.Invalid(kIntSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, int(v))
+		}
+		if vals != nil {
+			config.IntSlice = vals
+		}
+	}
+
+	if val, sep := l.Value(kInt8Slice); val != "" {
+		vals := []int8{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseInt(s, 10, 8)
+			if err != nil {
+				l.Invalid(kInt8Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, int8(v))
+		}
+		if vals != nil {
+			config.Int8Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kInt16Slice); val != "" {
+		vals := []int16{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseInt(s, 10, 16)
+			if err != nil {
+				l.Invalid(kInt16Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, int16(v))
+		}
+		if vals != nil {
+			config.Int16Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kInt32Slice); val != "" {
+		vals := []int32{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseInt(s, 10, 32)
+			if err != nil {
+				l.Invalid(kInt32Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, int32(v))
+		}
+		if vals != nil {
+			config.Int32Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kInt64Slice); val != "" {
+		vals := []int64{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				l.Invalid(kInt64Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.Int64Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kDurationSlice); val != "" {
+		vals := []time.Duration{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := time.ParseDuration(s)
+			if err != nil {
+				l.Invalid(kDurationSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.DurationSlice = vals
+		}
+	}
+
+	if val, sep := l.Value(kUintSlice); val != "" {
+		vals := []uint{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				l.Invalid(kUintSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, uint(v))
+		}
+		if vals != nil {
+			config.UintSlice = vals
+		}
+	}
+
+	if val, sep := l.Value(kUint8Slice); val != "" {
+		vals := []uint8{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseUint(s, 10, 8)
+			if err != nil {
+				l.Invalid(kUint8Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, uint8(v))
+		}
+		if vals != nil {
+			config.Uint8Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kUint16Slice); val != "" {
+		vals := []uint16{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseUint(s, 10, 16)
+			if err != nil {
+				l.Invalid(kUint16Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, uint16(v))
+		}
+		if vals != nil {
+			config.Uint16Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kUint32Slice); val != "" {
+		vals := []uint32{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseUint(s, 10, 32)
+			if err != nil {
+				l.Invalid(kUint32Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, uint32(v))
+		}
+		if vals != nil {
+			config.Uint32Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kUint64Slice); val != "" {
+		vals := []uint64{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				l.Invalid(kUint64Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.Uint64Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kURLSlice); val != "" {
+		vals := []url.URL{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := url.Parse(s)
+			if err != nil {
+				l.Invalid(kURLSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, *v)
+		}
+		if vals != nil {
+			config.URLSlice = vals
+		}
+	}
+
+	if val, sep := l.Value(kRegexpSlice); val != "" {
+		vals := []regexp.Regexp{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := regexp.CompilePOSIX(s)
+			if err != nil {
+				l.Invalid(kRegexpSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, *v)
+		}
+		if vals != nil {
+			config.RegexpSlice = vals
+		}
+	}
+
+	if val := l.Bytes(kBytes); val != nil {
+		config.Bytes = val
+	}
+
+	if val, _ := l.Value(kCertificate); val != "" {
+		if v, err := konfig.ParseCertificate(val); err == nil {
+			config.Certificate = *v
+		} else {
+			l.Invalid(kCertificate, err)
+		}
+	}
+
+	if val, _ := l.Value(kCertificatePtr); val != "" {
+		if v, err := konfig.ParseCertificate(val); err == nil {
+			config.CertificatePtr = v
+		} else {
+			l.Invalid(kCertificatePtr, err)
+		}
+	}
+
+	if val, _ := l.Value(kCertPool); val != "" {
+		if v, err := konfig.ParseCertPool(val); err == nil {
+			config.CertPool = *v
+		} else {
+			l.Invalid(kCertPool, err)
+		}
+	}
+
+	if val, _ := l.Value(kCertPoolPtr); val != "" {
+		if v, err := konfig.ParseCertPool(val); err == nil {
+			config.CertPoolPtr = v
+		} else {
+			l.Invalid(kCertPoolPtr, err)
+		}
+	}
+
+	if val, _ := l.Value(kIP); val != "" {
+		if v, err := konfig.ParseIP(val); err == nil {
+			config.IP = v
+		} else {
+			l.Invalid(kIP, err)
+		}
+	}
+
+	if val, _ := l.Value(kIPPtr); val != "" {
+		if v, err := konfig.ParseIP(val); err == nil {
+			p := v
+			config.IPPtr = &p
+		} else {
+			l.Invalid(kIPPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kIPSlice); val != "" {
+		vals := []net.IP{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := konfig.ParseIP(s)
+			if err != nil {
+				l.Invalid(kIPSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.IPSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kIPNet); val != "" {
+		if v, err := konfig.ParseIPNet(val); err == nil {
+			config.IPNet = *v
+		} else {
+			l.Invalid(kIPNet, err)
+		}
+	}
+
+	if val, _ := l.Value(kIPNetPtr); val != "" {
+		if v, err := konfig.ParseIPNet(val); err == nil {
+			config.IPNetPtr = v
+		} else {
+			l.Invalid(kIPNetPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kIPNetSlice); val != "" {
+		vals := []net.IPNet{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := konfig.ParseIPNet(s)
+			if err != nil {
+				l.Invalid(kIPNetSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, *v)
+		}
+		if vals != nil {
+			config.IPNetSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kAddr); val != "" {
+		if v, err := netip.ParseAddr(val); err == nil {
+			config.Addr = v
+		} else {
+			l.Invalid(kAddr, err)
+		}
+	}
+
+	if val, _ := l.Value(kAddrPtr); val != "" {
+		if v, err := netip.ParseAddr(val); err == nil {
+			p := v
+			config.AddrPtr = &p
+		} else {
+			l.Invalid(kAddrPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kAddrSlice); val != "" {
+		vals := []netip.Addr{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := netip.ParseAddr(s)
+			if err != nil {
+				l.Invalid(kAddrSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.AddrSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kPrefix); val != "" {
+		if v, err := netip.ParsePrefix(val); err == nil {
+			config.Prefix = v
+		} else {
+			l.Invalid(kPrefix, err)
+		}
+	}
+
+	if val, _ := l.Value(kPrefixPtr); val != "" {
+		if v, err := netip.ParsePrefix(val); err == nil {
+			p := v
+			config.PrefixPtr = &p
+		} else {
+			l.Invalid(kPrefixPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kPrefixSlice); val != "" {
+		vals := []netip.Prefix{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := netip.ParsePrefix(s)
+			if err != nil {
+				l.Invalid(kPrefixSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.PrefixSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kAddrPort); val != "" {
+		if v, err := netip.ParseAddrPort(val); err == nil {
+			config.AddrPort = v
+		} else {
+			l.Invalid(kAddrPort, err)
+		}
+	}
+
+	if val, _ := l.Value(kAddrPortPtr); val != "" {
+		if v, err := netip.ParseAddrPort(val); err == nil {
+			p := v
+			config.AddrPortPtr = &p
+		} else {
+			l.Invalid(kAddrPortPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kAddrPortSlice); val != "" {
+		vals := []netip.AddrPort{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := netip.ParseAddrPort(s)
+			if err != nil {
+				l.Invalid(kAddrPortSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.AddrPortSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kTime); val != "" {
+		if v, err := konfig.ParseTime(val, ""); err == nil {
+			config.Time = v
+		} else {
+			l.Invalid(kTime, err)
+		}
+	}
+
+	if val, _ := l.Value(kTimePtr); val != "" {
+		if v, err := konfig.ParseTime(val, ""); err == nil {
+			p := v
+			config.TimePtr = &p
+		} else {
+			l.Invalid(kTimePtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kTimeSlice); val != "" {
+		vals := []time.Time{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := konfig.ParseTime(s, "")
+			if err != nil {
+				l.Invalid(kTimeSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.TimeSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kDate); val != "" {
+		if v, err := konfig.ParseTime(val, "DateOnly"); err == nil {
+			config.Date = v
+		} else {
+			l.Invalid(kDate, err)
+		}
+	}
+
+	if val, _ := l.Value(kKitchen); val != "" {
+		if v, err := konfig.ParseTime(val, "3:04PM"); err == nil {
+			p := v
+			config.Kitchen = &p
+		} else {
+			l.Invalid(kKitchen, err)
+		}
+	}
+
+	if val, _ := l.Value(kLocation); val != "" {
+		if v, err := time.LoadLocation(val); err == nil {
+			config.Location = v
+		} else {
+			l.Invalid(kLocation, err)
+		}
+	}
+
+	if val, sep := l.Value(kLocationSlice); val != "" {
+		vals := []*time.Location{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := time.LoadLocation(s)
+			if err != nil {
+				l.Invalid(kLocationSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.LocationSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kTimeOfDay); val != "" {
+		if v, err := konfig.ParseTimeOfDay(val); err == nil {
+			config.TimeOfDay = v
+		} else {
+			l.Invalid(kTimeOfDay, err)
+		}
+	}
+
+	if val, _ := l.Value(kTimeOfDayPtr); val != "" {
+		if v, err := konfig.ParseTimeOfDay(val); err == nil {
+			p := v
+			config.TimeOfDayPtr = &p
+		} else {
+			l.Invalid(kTimeOfDayPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kTimeOfDaySlice); val != "" {
+		vals := []konfig.TimeOfDay{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := konfig.ParseTimeOfDay(s)
+			if err != nil {
+				l.Invalid(kTimeOfDaySlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.TimeOfDaySlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kByteSize); val != "" {
+		if v, err := konfig.ParseByteSize(val); err == nil {
+			config.ByteSize = v
+		} else {
+			l.Invalid(kByteSize, err)
+		}
+	}
+
+	if val, _ := l.Value(kByteSizePtr); val != "" {
+		if v, err := konfig.ParseByteSize(val); err == nil {
+			p := v
+			config.ByteSizePtr = &p
+		} else {
+			l.Invalid(kByteSizePtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kByteSizeSlice); val != "" {
+		vals := []konfig.ByteSize{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := konfig.ParseByteSize(s)
+			if err != nil {
+				l.Invalid(kByteSizeSlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.ByteSizeSlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kQuantity); val != "" {
+		if v, err := konfig.ParseQuantity(val); err == nil {
+			config.Quantity = v
+		} else {
+			l.Invalid(kQuantity, err)
+		}
+	}
+
+	if val, _ := l.Value(kQuantityPtr); val != "" {
+		if v, err := konfig.ParseQuantity(val); err == nil {
+			p := v
+			config.QuantityPtr = &p
+		} else {
+			l.Invalid(kQuantityPtr, err)
+		}
+	}
+
+	if val, sep := l.Value(kQuantitySlice); val != "" {
+		vals := []konfig.Quantity{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := konfig.ParseQuantity(s)
+			if err != nil {
+				l.Invalid(kQuantitySlice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.QuantitySlice = vals
+		}
+	}
+
+	if val, _ := l.Value(kCacheSize); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.CacheSize = v
+		} else {
+			l.Invalid(kCacheSize, err)
+		}
+	}
+
+	if val, sep := l.Value(kUploadLimits); val != "" {
+		vals := []uint{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				l.Invalid(kUploadLimits, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, uint(v))
+		}
+		if vals != nil {
+			config.UploadLimits = vals
+		}
+	}
+
+	l.Structs(kUpstreams, &config.Upstreams)
+
+	l.Structs(kBackends, &config.Backends)
+
+	if val, _ := l.Value(kRoutes); val != "" {
+		var v map[string]time.Duration
+		if err := konfig.Unmarshal("json", val, &v); err == nil {
+			config.Routes = v
+		} else {
+			l.Invalid(kRoutes, err)
+		}
+	}
+
+	if val, _ := l.Value(kPrimary); val != "" {
+		var v *Upstream
+		if err := konfig.Unmarshal("yaml", val, &v); err == nil {
+			config.Primary = v
+		} else {
+			l.Invalid(kPrimary, err)
+		}
+	}
+
+	if val, _ := l.Value(kMatrix); val != "" {
+		var v [][]int
+		if err := konfig.Unmarshal("json", val, &v); err == nil {
+			config.Matrix = v
+		} else {
+			l.Invalid(kMatrix, err)
+		}
+	}
+
+	if val, _ := l.Value(kComplex64); val != "" {
+		if v, err := strconv.ParseComplex(val, 64); err == nil {
+			config.Complex64 = complex64(v)
+		} else {
+			l.Invalid(kComplex64, err)
+		}
+	}
+
+	if val, _ := l.Value(kComplex128); val != "" {
+		if v, err := strconv.ParseComplex(val, 128); err == nil {
+			config.Complex128 = v
+		} else {
+			l.Invalid(kComplex128, err)
+		}
+	}
+
+	if val, _ := l.Value(kComplex64Ptr); val != "" {
+		if v, err := strconv.ParseComplex(val, 64); err == nil {
+			p := complex64(v)
+			config.Complex64Ptr = &p
+		} else {
+			l.Invalid(kComplex64Ptr, err)
+		}
+	}
+
+	if val, sep := l.Value(kComplex128Slice); val != "" {
+		vals := []complex128{}
+		for _, s := range strings.Split(val, sep) {
+			v, err := strconv.ParseComplex(s, 128)
+			if err != nil {
+				l.Invalid(kComplex128Slice, err)
+				vals = nil
+				break
+			}
+			vals = append(vals, v)
+		}
+		if vals != nil {
+			config.Complex128Slice = vals
+		}
+	}
+
+	if val, sep := l.Value(kWeights); val != "" {
+		if parts := strings.Split(val, sep); len(parts) != len(config.Weights) {
+			l.Invalid(kWeights, fmt.Errorf("invalid number of values: expected %d, got %d", len(config.Weights), len(parts)))
+		} else {
+			var vals [3]float64
+			n := 0
+			for i, s := range parts {
+				v, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					l.Invalid(kWeights, err)
+					break
+				}
+				vals[i] = v
+				n++
+			}
+			if n == len(vals) {
+				config.Weights = vals
+			}
+		}
+	}
+
+	if val, sep := l.Value(kPair); val != "" {
+		if vals := strings.Split(val, sep); len(vals) != len(config.Pair) {
+			l.Invalid(kPair, fmt.Errorf("invalid number of values: expected %d, got %d", len(config.Pair), len(vals)))
+		} else {
+			copy(config.Pair[:], vals)
+		}
+	}
+
+	if val, sep := l.Value(kTimeouts); val != "" {
+		if parts := strings.Split(val, sep); len(parts) != len(config.Timeouts) {
+			l.Invalid(kTimeouts, fmt.Errorf("invalid number of values: expected %d, got %d", len(config.Timeouts), len(parts)))
+		} else {
+			var vals [2]time.Duration
+			n := 0
+			for i, s := range parts {
+				v, err := time.ParseDuration(s)
+				if err != nil {
+					l.Invalid(kTimeouts, err)
+					break
+				}
+				vals[i] = v
+				n++
+			}
+			if n == len(vals) {
+				config.Timeouts = vals
+			}
+		}
+	}
+
+	if val, sep := l.Value(kGateways); val != "" {
+		if parts := strings.Split(val, sep); len(parts) != len(config.Gateways) {
+			l.Invalid(kGateways, fmt.Errorf("invalid number of values: expected %d, got %d", len(config.Gateways), len(parts)))
+		} else {
+			var vals [2]net.IP
+			n := 0
+			for i, s := range parts {
+				v, err := konfig.ParseIP(s)
+				if err != nil {
+					l.Invalid(kGateways, err)
+					break
+				}
+				vals[i] = v
+				n++
+			}
+			if n == len(vals) {
+				config.Gateways = vals
+			}
+		}
+	}
+
+	if val, sep := l.Value(kWindow); val != "" {
+		if parts := strings.Split(val, sep); len(parts) != len(config.Window) {
+			l.Invalid(kWindow, fmt.Errorf("invalid number of values: expected %d, got %d", len(config.Window), len(parts)))
+		} else {
+			var vals [2]konfig.TimeOfDay
+			n := 0
+			for i, s := range parts {
+				v, err := konfig.ParseTimeOfDay(s)
+				if err != nil {
+					l.Invalid(kWindow, err)
+					break
+				}
+				vals[i] = v
+				n++
+			}
+			if n == len(vals) {
+				config.Window = vals
+			}
+		}
+	}
+
+	if val, sep := l.Value(kOctets); val != "" {
+		if parts := strings.Split(val, sep); len(parts) != len(config.Octets) {
+			l.Invalid(kOctets, fmt.Errorf("invalid number of values: expected %d, got %d", len(config.Octets), len(parts)))
+		} else {
+			var vals [4]uint8
+			n := 0
+			for i, s := range parts {
+				v, err := strconv.ParseUint(s, 10, 8)
+				if err != nil {
+					l.Invalid(kOctets, err)
+					break
+				}
+				vals[i] = uint8(v)
+				n++
+			}
+			if n == len(vals) {
+				config.Octets = vals
+			}
+		}
+	}
+
+	if val, _ := l.Value(kLevel); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.Level = Level(int(v))
+		} else {
+			l.Invalid(kLevel, err)
+		}
+	}
+
+	if val, _ := l.Value(kSeverity); val != "" {
+		if v, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.Severity = Severity(int(v))
+		} else {
+			l.Invalid(kSeverity, err)
+		}
+	}
+
+	if val, _ := l.Value(kMode); val != "" {
+		config.Mode = Mode(val)
+	}
+
+	return l.Err()
+}
@@ -0,0 +1,332 @@
+package fixture
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/moorara/konfig"
+	"github.com/moorara/konfig/konfigtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	type env struct {
+		varName string
+		value   string
+	}
+
+	type file struct {
+		varName string
+		value   string
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		envs  []env
+		files []file
+		opts  []konfig.Option
+	}{
+		{
+			name: "Defaults",
+			args: []string{"app"},
+		},
+		{
+			name: "Flags",
+			args: []string{
+				"app",
+				"-fixture.custom", "custom",
+				"-string", "content",
+				"-bool",
+				"-float32=3.1415",
+				"-int=-2147483648",
+				"-duration=90m",
+				"-uint64", "18446744073709551615",
+				"-url=https://example.com",
+				"-regexp=[:digit:]",
+				"-int.ptr=27",
+				"-url.ptr=https://example.com",
+				"-string.slice=foo,bar",
+				"-uint8.slice=0|255",
+				"-duration.slice=1s,1m",
+				"-regexp.slice=[:alpha:],[:digit:]",
+				"-ip=10.0.0.1",
+				"-ip.net.ptr=10.0.0.0/8",
+				"-addr.slice=10.0.0.1,::1",
+				"-prefix=192.168.0.0/16",
+				"-addr.port.ptr=127.0.0.1:8080",
+				"-time=2024-03-01T09:30:00+01:00",
+				"-date=2024-03-01",
+				"-location=America/New_York",
+				"-time.of.day.slice=09:00,17:30:15",
+				"-byte.size=1.5GiB",
+				"-quantity.slice=500m,2,1Ki",
+				"-cache.size=512KiB",
+				"-upstreams.0.host=a.example.com",
+				"-upstreams.0.port=8080",
+				"-upstreams.1.host=b.example.com",
+				`-backends=[{"host":"c.example.com","port":80,"weight":0.5,"tags":["x","y"],"limit":"1MiB"}]`,
+				`-matrix=[[1,2],[3,4]]`,
+				"-complex64=1+2i",
+				"-complex128.slice=1,2i,(3-4i)",
+				"-weights=0.2,0.3,0.5",
+				"-window=09:00,17:00",
+				"-level=-1",
+				"-mode=debug",
+			},
+		},
+		{
+			name: "EnvironmentVariables",
+			args: []string{"app"},
+			envs: []env{
+				{"SKIP_FLAG", "skipped"},
+				{"IGNORED", "ignored"},
+				{"FIXTURE_CUSTOM", "custom"},
+				{"BOOL", "true"},
+				{"ENCODED", "Y29udGVudA=="},
+				{"FLOAT64", "3.14159265359"},
+				{"INT8", "-128"},
+				{"UINT16", "65535"},
+				{"STRING_PTR", "content"},
+				{"BOOL_PTR", "true"},
+				{"FLOAT32_PTR", "3.1415"},
+				{"DURATION_PTR", "1h"},
+				{"REGEXP_PTR", "[:alpha:]"},
+				{"INT_SLICE", "-1,0,1"},
+				{"FLOAT32_SLICE", "3.1415,2.7182"},
+				{"URL_SLICE", "https://a.example.com,https://b.example.com"},
+				{"BYTES", "AAEC/w=="},
+				{"CERTIFICATE", "../../../../testdata/tls/server.crt" + string(os.PathListSeparator) + "../../../../testdata/tls/server.key"},
+				{"IP_PTR", "::1"},
+				{"IP_SLICE", "10.0.0.1,10.0.0.2"},
+				{"IP_NET", "fd00::/8"},
+				{"IP_NET_SLICE", "10.0.0.0/8,172.16.0.0/12"},
+				{"ADDR", "192.168.1.1"},
+				{"PREFIX_PTR", "10.0.0.0/8"},
+				{"PREFIX_SLICE", "10.0.0.0/8,::/0"},
+				{"ADDR_PORT", "[::1]:443"},
+				{"ADDR_PORT_SLICE", "10.0.0.1:80,10.0.0.2:80"},
+				{"TIME_PTR", "2024-03-01T09:30:00Z"},
+				{"TIME_SLICE", "2024-03-01T00:00:00Z,2024-03-02T00:00:00-05:00"},
+				{"KITCHEN", "9:30AM"},
+				{"LOCATION_SLICE", "UTC,Europe/Berlin"},
+				{"TIME_OF_DAY", "9:30"},
+				{"BYTE_SIZE_PTR", "10MB"},
+				{"QUANTITY", "250m"},
+				{"UPLOAD_LIMITS", "1Mi,10 MB,1024"},
+				{"UPSTREAMS_0_HOST", "a.example.com"},
+				{"UPSTREAMS_0_WEIGHT", "0.5"},
+				{"UPSTREAMS_0_TAGS", "x,y"},
+				{"UPSTREAMS_1_LIMIT", "1KiB"},
+				{"BACKENDS_LIST_0_PORT", "443"},
+				{"ROUTES", `{"/api": 1000000000, "/health": 5000000}`},
+				{"COMPLEX128", "2.5-1.5i"},
+				{"COMPLEX64_PTR", "3i"},
+				{"PAIR", "primary,secondary"},
+				{"GATEWAYS", "10.0.0.1,::1"},
+				{"OCTETS", "10.0.0.1"},
+				{"SEVERITY", "3"},
+			},
+		},
+		{
+			name: "Files",
+			args: []string{"app"},
+			files: []file{
+				{"SKIP_FLAG_ENV_FILE", "skipped"},
+				{"FIXTURE_CUSTOM_PATH", "custom"},
+				{"STRING_FILE", "content"},
+				{"INT64_FILE", "-9223372036854775808"},
+				{"UINT_FILE", "27\n"},
+				{"ENCODED_FILE", "Y29udGVudA==\n"},
+				{"BYTES_FILE", "\x00\x01\x02\xff\n"},
+				{"CERTIFICATE_PTR_FILE", readFile(t, "../../../../testdata/tls/client.crt") + readFile(t, "../../../../testdata/tls/client.key")},
+				{"UINT32_PTR_FILE", "4294967295"},
+				{"BOOL_SLICE_FILE", "true,false"},
+				{"UINT64_SLICE_FILE", "0,18446744073709551615"},
+				{"TIME_OF_DAY_PTR_FILE", "23:59:59\n"},
+				{"BYTE_SIZE_SLICE_FILE", "1KiB,1KB\n"},
+				{"QUANTITY_PTR_FILE", "1.5Gi\n"},
+				{"UPSTREAMS_0_HOST_FILE", "a.example.com\n"},
+				{"UPSTREAMS_1_PORT_FILE", "8080"},
+				{"BACKENDS_LIST_FILE", `[{"host": "c.example.com"}]`},
+				{"PRIMARY_FILE", "host: a.example.com\nport: 8080\ntags: [x, y]\n"},
+				{"TIMEOUTS_FILE", "1s,1m\n"},
+				{"COMPLEX64_FILE", "1e3+1e-3i"},
+			},
+		},
+		{
+			name: "InvalidValues",
+			args: []string{"app", "-int=invalid", "-url=:invalid"},
+			envs: []env{
+				{"BOOL", "invalid"},
+				{"INT8_PTR", "1000"},
+				{"INT_SLICE", "1,invalid"},
+				{"ENCODED", "!invalid!"},
+				{"BYTES", "!invalid!"},
+				{"CERTIFICATE", "/tmp/missing.crt"},
+				{"IP", "10.0.0.256"},
+				{"IP_NET_PTR", "10.0.0.0"},
+				{"ADDR_SLICE", "10.0.0.1,invalid"},
+				{"ADDR_PORT_PTR", "10.0.0.1"},
+				{"TIME", "2024-03-01"},
+				{"DATE", "2024-03-01T09:30:00Z"},
+				{"LOCATION", "Mars/Olympus_Mons"},
+				{"TIME_OF_DAY", "24:00"},
+				{"BYTE_SIZE", "1.5B"},
+				{"QUANTITY_SLICE", "1,2x"},
+				{"CACHE_SIZE", "10XB"},
+				{"UPSTREAMS_0_PORT", "invalid"},
+				{"BACKENDS_LIST", `[{"unknown": true}]`},
+				{"ROUTES", `{"/api": "1s"}`},
+				{"PRIMARY", "host: [invalid"},
+				{"COMPLEX64", "1+2j"},
+				{"COMPLEX128_SLICE", "1,invalid"},
+				{"WEIGHTS", "0.5,0.5"},
+				{"PAIR", "a,b,c"},
+				{"TIMEOUTS", "1s,invalid"},
+				{"GATEWAYS", "10.0.0.1,10.0.0.256"},
+				{"LEVEL", "high"},
+			},
+		},
+		{
+			name: "WithOptions",
+			args: []string{"app", "-config.string=content"},
+			envs: []env{
+				{"CONFIG_INT_SLICE", "1;2;3"},
+			},
+			opts: []konfig.Option{
+				konfig.PrefixFlag("config."),
+				konfig.PrefixEnv("CONFIG_"),
+				konfig.ListSep(";"),
+			},
+		},
+		{
+			name: "Strict",
+			args: []string{"app", "-strng=content"},
+			envs: []env{
+				{"CONFIG_INTT", "27"},
+			},
+			opts: []konfig.Option{
+				konfig.Strict(),
+				konfig.PrefixEnv("CONFIG_"),
+			},
+		},
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+
+			for _, e := range tc.envs {
+				err := os.Setenv(e.varName, e.value)
+				assert.NoError(t, err)
+				defer os.Unsetenv(e.varName)
+			}
+
+			for _, f := range tc.files {
+				tmpfile, err := ioutil.TempFile("", "gotest_")
+				assert.NoError(t, err)
+				defer os.Remove(tmpfile.Name())
+
+				_, err = tmpfile.WriteString(f.value)
+				assert.NoError(t, err)
+
+				err = tmpfile.Close()
+				assert.NoError(t, err)
+
+				err = os.Setenv(f.varName, tmpfile.Name())
+				assert.NoError(t, err)
+				defer os.Unsetenv(f.varName)
+			}
+
+			newConfig := func() *Config {
+				return &Config{
+					String: "default",
+					Int:    27,
+				}
+			}
+
+			konfigtest.Agree(t, newConfig, LoadConfig, tc.opts...)
+		})
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+// Pools of certificates cannot be compared using reflect.DeepEqual.
+func TestLoadConfigCertPool(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	err := os.Setenv("CERT_POOL", "../../../../testdata/tls/ca.crt")
+	assert.NoError(t, err)
+	defer os.Unsetenv("CERT_POOL")
+
+	err = os.Setenv("CERT_POOL_PTR", readFile(t, "../../../../testdata/tls/ca.crt"))
+	assert.NoError(t, err)
+	defer os.Unsetenv("CERT_POOL_PTR")
+
+	c1 := &Config{}
+	err = konfig.Pick(c1)
+	assert.NoError(t, err)
+
+	c2 := &Config{}
+	err = LoadConfig(c2)
+	assert.NoError(t, err)
+
+	assert.NotNil(t, c2.CertPoolPtr)
+	assert.True(t, c1.CertPool.Equal(&c2.CertPool))
+	assert.True(t, c1.CertPoolPtr.Equal(c2.CertPoolPtr))
+}
+
+var benchmarkArgs = []string{"app", "-string=content", "-int=27", "-duration=90m", "-string.slice=foo,bar", "-ip=10.0.0.1", "-byte.size=1.5GiB"}
+
+func BenchmarkLoadConfig(b *testing.B) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = benchmarkArgs
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c := &Config{}
+		if err := LoadConfig(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPick is the same as BenchmarkLoadConfig using reflection for comparison.
+func BenchmarkPick(b *testing.B) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = benchmarkArgs
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c := &Config{}
+		if err := konfig.Pick(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// konfig-gen generates a loader function for a configuration struct that parses and sets values without reflection.
+// The generated function follows the same rules as konfig.Pick for naming, precedence, parsing, and errors.
+//
+// Usage:
+//
+//	//go:generate go run github.com/moorara/konfig/cmd/konfig-gen -type Config
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var typeName, funcName, output string
+
+	flag.StringVar(&typeName, "type", "", "name of the struct type (required)")
+	flag.StringVar(&funcName, "func", "", "name of the generated function (default Load<type>)")
+	flag.StringVar(&output, "output", "", "output file name (default <type>_konfig.go)")
+	flag.Parse()
+
+	if typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	if output == "" {
+		output = filepath.Join(dir, strings.ToLower(typeName)+"_konfig.go")
+	}
+
+	src, err := generate(dir, typeName, funcName, output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "konfig-gen: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "konfig-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
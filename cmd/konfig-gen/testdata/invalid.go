@@ -0,0 +1,30 @@
+package testdata
+
+type Level string
+
+type NotStruct int
+
+type Unsupported struct {
+	Level  Level
+	Levels []Level
+}
+
+type Skipped struct {
+	Map    map[string]string
+	Func   func()
+	Array  [2][3]int
+	Arrays [2][]int
+	Level  Level `konfig:"-"`
+	Value  string
+}
+
+type UnknownFormat struct {
+	Routes map[string]string `format:"xml"`
+}
+
+type Priority int
+
+type Named struct {
+	Level    Level
+	Priority Priority
+}
@@ -0,0 +1,227 @@
+package konfig
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultQueueSize is the maximum number of pending updates for each subscriber if no size is set using Backpressure option.
+const defaultQueueSize = 64
+
+// BackpressurePolicy determines what happens to a new update when a subscriber's queue is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits until the subscriber receives a pending update and there is room in its queue.
+	// The configuration struct is not locked while waiting, so subscribers can lock it.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest discards the oldest pending update to make room for the new one.
+	BackpressureDropOldest
+	// BackpressureDropNewest discards the new update and keeps the pending ones.
+	BackpressureDropNewest
+	// BackpressureCoalesce replaces a pending update for the same field with the new one.
+	// If there is no pending update for the same field, the oldest pending update is discarded.
+	BackpressureCoalesce
+)
+
+// String returns a human-readable name for a backpressure policy.
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressureBlock:
+		return "Block"
+	case BackpressureDropOldest:
+		return "DropOldest"
+	case BackpressureDropNewest:
+		return "DropNewest"
+	case BackpressureCoalesce:
+		return "Coalesce"
+	}
+
+	return fmt.Sprintf("BackpressurePolicy(%d)", int(p))
+}
+
+// DeliveryMetrics keeps statistics about delivering updates to subscribers.
+// It is safe to read the metrics while updates are being delivered.
+type DeliveryMetrics struct {
+	delivered uint64
+	dropped   uint64
+	coalesced uint64
+}
+
+// Delivered returns the number of updates received by subscribers.
+func (m *DeliveryMetrics) Delivered() uint64 {
+	return atomic.LoadUint64(&m.delivered)
+}
+
+// Dropped returns the number of updates discarded because a subscriber was full.
+func (m *DeliveryMetrics) Dropped() uint64 {
+	return atomic.LoadUint64(&m.dropped)
+}
+
+// Coalesced returns the number of pending updates replaced by newer updates for the same field.
+func (m *DeliveryMetrics) Coalesced() uint64 {
+	return atomic.LoadUint64(&m.coalesced)
+}
+
+func (m *DeliveryMetrics) incDelivered() {
+	if m != nil {
+		atomic.AddUint64(&m.delivered, 1)
+	}
+}
+
+func (m *DeliveryMetrics) incDropped() {
+	if m != nil {
+		atomic.AddUint64(&m.dropped, 1)
+	}
+}
+
+func (m *DeliveryMetrics) incCoalesced() {
+	if m != nil {
+		atomic.AddUint64(&m.coalesced, 1)
+	}
+}
+
+// queue delivers updates to a single subscriber channel in the order they are pushed.
+// A size of zero means the queue is unbounded and the backpressure policy never applies.
+// Pending updates with the same key are coalesced when the policy is BackpressureCoalesce.
+type queue[T any] struct {
+	id      int
+	ch      chan T
+	key     func(T) string
+	policy  BackpressurePolicy
+	size    int
+	metrics *DeliveryMetrics
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending []T
+	closed  bool
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newQueue[T any](id int, ch chan T, key func(T) string, policy BackpressurePolicy, size int, metrics *DeliveryMetrics) *queue[T] {
+	q := &queue[T]{
+		id:      id,
+		ch:      ch,
+		key:     key,
+		policy:  policy,
+		size:    size,
+		metrics: metrics,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	q.cond = sync.NewCond(&q.mu)
+	go q.run()
+
+	return q
+}
+
+// push adds an update to the queue and applies the backpressure policy if the queue is full.
+// It reports whether the update was queued.
+func (q *queue[T]) push(u T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return false
+	}
+
+	if q.size > 0 && len(q.pending) >= q.size {
+		switch q.policy {
+		case BackpressureBlock:
+			for len(q.pending) >= q.size && !q.closed {
+				q.cond.Wait()
+			}
+			if q.closed {
+				return false
+			}
+
+		case BackpressureDropOldest:
+			q.pending = q.pending[1:]
+			q.metrics.incDropped()
+
+		case BackpressureDropNewest:
+			q.metrics.incDropped()
+			return false
+
+		case BackpressureCoalesce:
+			for i := range q.pending {
+				if q.key(q.pending[i]) == q.key(u) {
+					q.pending[i] = u
+					q.metrics.incCoalesced()
+					return true
+				}
+			}
+			q.pending = q.pending[1:]
+			q.metrics.incDropped()
+		}
+	}
+
+	q.pending = append(q.pending, u)
+	q.cond.Broadcast()
+
+	return true
+}
+
+// run sends the pending updates to the subscriber channel one at a time.
+func (q *queue[T]) run() {
+	defer close(q.stopped)
+
+	for {
+		q.mu.Lock()
+		for len(q.pending) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+
+		if q.closed {
+			q.mu.Unlock()
+			return
+		}
+
+		u := q.pending[0]
+		q.pending = q.pending[1:]
+		q.cond.Broadcast()
+		q.mu.Unlock()
+
+		select {
+		case q.ch <- u:
+			q.metrics.incDelivered()
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// close stops delivering updates and waits until no more updates are sent to the subscriber channel.
+// Pending updates are discarded.
+func (q *queue[T]) close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.done)
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	<-q.stopped
+}
+
+// deliveryPolicy returns the backpressure policy and the size for delivery queues.
+// If Backpressure option is not used, pending updates for the same field are coalesced and queues are bounded by the default size.
+func (r *reader) deliveryPolicy() (BackpressurePolicy, int) {
+	if r.queueSize <= 0 {
+		return BackpressureCoalesce, defaultQueueSize
+	}
+
+	return r.backpressure, r.queueSize
+}
+
+// updateKey is the key for coalescing updates for the same field.
+func updateKey(u Update) string {
+	return u.Name
+}
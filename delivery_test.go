@@ -0,0 +1,202 @@
+package konfig
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackpressurePolicyString(t *testing.T) {
+	tests := []struct {
+		policy         BackpressurePolicy
+		expectedString string
+	}{
+		{BackpressureBlock, "Block"},
+		{BackpressureDropOldest, "DropOldest"},
+		{BackpressureDropNewest, "DropNewest"},
+		{BackpressureCoalesce, "Coalesce"},
+		{BackpressurePolicy(-1), "BackpressurePolicy(-1)"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expectedString, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.policy.String())
+		})
+	}
+}
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name              string
+		policy            BackpressurePolicy
+		size              int
+		updates           []Update
+		expectedUpdates   []Update
+		expectedDelivered uint64
+		expectedDropped   uint64
+		expectedCoalesced uint64
+	}{
+		{
+			name:   "Unbounded",
+			policy: BackpressureDropNewest,
+			size:   0,
+			updates: []Update{
+				{"A", 1}, {"B", 2}, {"A", 3}, {"C", 4},
+			},
+			expectedUpdates: []Update{
+				{"A", 1}, {"B", 2}, {"A", 3}, {"C", 4},
+			},
+			expectedDelivered: 4,
+		},
+		{
+			name:   "DropOldest",
+			policy: BackpressureDropOldest,
+			size:   2,
+			updates: []Update{
+				{"A", 1}, {"B", 2}, {"A", 3}, {"C", 4},
+			},
+			expectedUpdates: []Update{
+				{"A", 3}, {"C", 4},
+			},
+			expectedDelivered: 2,
+			expectedDropped:   2,
+		},
+		{
+			name:   "DropNewest",
+			policy: BackpressureDropNewest,
+			size:   2,
+			updates: []Update{
+				{"A", 1}, {"B", 2}, {"A", 3}, {"C", 4},
+			},
+			expectedUpdates: []Update{
+				{"A", 1}, {"B", 2},
+			},
+			expectedDelivered: 2,
+			expectedDropped:   2,
+		},
+		{
+			name:   "Coalesce",
+			policy: BackpressureCoalesce,
+			size:   2,
+			updates: []Update{
+				{"A", 1}, {"B", 2}, {"A", 3}, {"C", 4},
+			},
+			expectedUpdates: []Update{
+				{"B", 2}, {"C", 4},
+			},
+			expectedDelivered: 2,
+			expectedDropped:   1,
+			expectedCoalesced: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ch := make(chan Update)
+			metrics := new(DeliveryMetrics)
+			q := &queue[Update]{
+				ch:      ch,
+				key:     updateKey,
+				policy:  tc.policy,
+				size:    tc.size,
+				metrics: metrics,
+				done:    make(chan struct{}),
+				stopped: make(chan struct{}),
+			}
+			q.cond = sync.NewCond(&q.mu)
+
+			// Queue all updates before the delivery starts, so the policy applies deterministically
+			for _, u := range tc.updates {
+				q.push(u)
+			}
+
+			go q.run()
+			defer q.close()
+
+			for _, expectedUpdate := range tc.expectedUpdates {
+				assert.Equal(t, expectedUpdate, <-ch)
+			}
+
+			q.close()
+			assert.Equal(t, tc.expectedDelivered, metrics.Delivered())
+			assert.Equal(t, tc.expectedDropped, metrics.Dropped())
+			assert.Equal(t, tc.expectedCoalesced, metrics.Coalesced())
+		})
+	}
+}
+
+func TestQueueBlock(t *testing.T) {
+	ch := make(chan Update)
+	q := newQueue(0, ch, updateKey, BackpressureBlock, 1, nil)
+
+	pushed := make(chan struct{})
+	go func() {
+		q.push(Update{"A", 1})
+		q.push(Update{"B", 2})
+		q.push(Update{"C", 3})
+		close(pushed)
+	}()
+
+	assert.Equal(t, Update{"A", 1}, <-ch)
+	assert.Equal(t, Update{"B", 2}, <-ch)
+	assert.Equal(t, Update{"C", 3}, <-ch)
+	<-pushed
+
+	q.close()
+	assert.False(t, q.push(Update{"D", 4}))
+}
+
+func TestQueueClose(t *testing.T) {
+	ch := make(chan Update)
+	q := newQueue(0, ch, updateKey, BackpressureBlock, 1, nil)
+
+	q.push(Update{"A", 1})
+	q.push(Update{"B", 2})
+
+	// Nobody receives from the channel, so both the delivery and the blocked push should be released by close
+	blocked := make(chan bool)
+	go func() {
+		blocked <- q.push(Update{"C", 3})
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.close()
+
+	assert.False(t, <-blocked)
+	q.close()
+}
+
+func TestReaderDeliveryPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		r              *reader
+		expectedPolicy BackpressurePolicy
+		expectedSize   int
+	}{
+		{
+			name:           "Default",
+			r:              &reader{},
+			expectedPolicy: BackpressureCoalesce,
+			expectedSize:   64,
+		},
+		{
+			name: "Custom",
+			r: &reader{
+				backpressure: BackpressureBlock,
+				queueSize:    10,
+			},
+			expectedPolicy: BackpressureBlock,
+			expectedSize:   10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, size := tc.r.deliveryPolicy()
+			assert.Equal(t, tc.expectedPolicy, policy)
+			assert.Equal(t, tc.expectedSize, size)
+		})
+	}
+}
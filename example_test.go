@@ -63,3 +63,83 @@ func ExampleWatch() {
 	close, _ := konfig.Watch(&config, []chan konfig.Update{ch})
 	defer close()
 }
+
+func ExampleLoad() {
+	type Config struct {
+		Enabled  bool
+		LogLevel string
+	}
+
+	// The type of config is Config and it is populated with the values read.
+	config, _ := konfig.Load[Config]()
+	fmt.Printf("%+v\n", config)
+}
+
+func ExampleNewLive() {
+	// When using a Live, your struct does not need any lock.
+	type Config struct {
+		LogLevel string
+	}
+
+	// Subscribers receive a snapshot of the whole struct every time a configuration value changes.
+	ch := make(chan Config, 1)
+
+	live, _ := konfig.NewLive(Config{
+		LogLevel: "info", // default
+	}, []chan Config{ch})
+	defer live.Close()
+
+	go func() {
+		for config := range ch {
+			_ = config.LogLevel
+			// logger.SetLevel(config.LogLevel)
+		}
+	}()
+
+	// Readers can load the latest values at any time without locking.
+	config := live.Load()
+	fmt.Printf("%+v\n", config)
+}
+
+func ExampleLoader() {
+	// A loader is created once with options and can be used many times.
+	loader := konfig.NewLoader(
+		konfig.PrefixEnv("APP_"),
+		konfig.PrefixFileEnv("APP_"),
+	)
+
+	var config = struct {
+		LogLevel string
+		Region   string
+	}{
+		LogLevel: "info", // default
+	}
+
+	_ = loader.Pick(&config)
+
+	// You can see where the value of each field is read from.
+	for _, f := range loader.Describe() {
+		fmt.Printf("%s: %s\n", f.Name, f.Source)
+	}
+}
+
+func ExampleWatchT() {
+	type Config struct {
+		LogLevel string
+	}
+
+	// Subscribers receive typed events every time a configuration value changes.
+	ch := make(chan konfig.Event[Config], 1)
+
+	live, _ := konfig.WatchT(Config{
+		LogLevel: "info", // default
+	}, []chan konfig.Event[Config]{ch})
+	defer live.Close()
+
+	go func() {
+		for e := range ch {
+			fmt.Printf("%s: %v\n", e.Name, e.Value)
+			// logger.SetLevel(e.Config.LogLevel)
+		}
+	}()
+}
@@ -0,0 +1,194 @@
+package konfig
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// expandValue expands references to environment variables and other fields in a value read for a field.
+//   - $VAR and ${VAR} are replaced by the value of environment variable VAR.
+//   - ${VAR:-default} is replaced by default if VAR is not set or empty.
+//   - ${.Field} is replaced by the value of another field.
+//   - $$ is replaced by a single $.
+func (r *reader) expandValue(name, val string) (string, error) {
+	return r.expandRefs(val, []string{name})
+}
+
+// expandRefs expands the references in a string.
+// stack is the chain of fields that are being expanded and is used for detecting cycles.
+func (r *reader) expandRefs(val string, stack []string) (string, error) {
+	// Fast path
+	if !strings.Contains(val, "$") {
+		return val, nil
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(val); i++ {
+		if val[i] != '$' || i+1 == len(val) {
+			b.WriteByte(val[i])
+			continue
+		}
+
+		switch next := val[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+
+		case next == '{':
+			end := closingBrace(val, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("missing closing brace in %q", val)
+			}
+
+			s, err := r.resolveRef(val[i+2:end], stack)
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(s)
+			i = end
+
+		case isNameStart(next):
+			j := i + 1
+			for j < len(val) && isNameChar(val[j]) {
+				j++
+			}
+
+			b.WriteString(os.Getenv(val[i+1 : j]))
+			i = j - 1
+
+		default:
+			b.WriteByte(val[i])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// resolveRef resolves a reference inside braces (i.e. VAR, VAR:-default, or .Field).
+func (r *reader) resolveRef(ref string, stack []string) (string, error) {
+	name, def, hasDef := ref, "", false
+	if i := strings.Index(ref, ":-"); i >= 0 {
+		name, def, hasDef = ref[:i], ref[i+2:], true
+	}
+
+	var val string
+
+	if strings.HasPrefix(name, ".") {
+		field := name[1:]
+
+		for _, s := range stack {
+			if s == field {
+				return "", fmt.Errorf("cycle in references: %s -> %s", strings.Join(stack, " -> "), field)
+			}
+		}
+
+		raw, ok := r.fieldValue(field)
+		if !ok {
+			return "", fmt.Errorf("unknown field in reference: %s", field)
+		}
+
+		var err error
+		if val, err = r.expandRefs(raw, append(stack, field)); err != nil {
+			return "", err
+		}
+	} else {
+		val = os.Getenv(name)
+	}
+
+	if val == "" && hasDef {
+		return r.expandRefs(def, stack)
+	}
+
+	return val, nil
+}
+
+// fieldValue returns the value read for a field before expansion.
+func (r *reader) fieldValue(name string) (string, bool) {
+	r.fieldsMu.Lock()
+	defer r.fieldsMu.Unlock()
+
+	val, ok := r.values[name]
+	return val, ok
+}
+
+// setFieldRawValue keeps the value read for a field, so other fields can refer to it.
+// If no value is read for the field, its default value is kept.
+func (r *reader) setFieldRawValue(name, val string, v reflect.Value, listSep string) {
+	if val == "" {
+		val = stringValue(v, listSep)
+	}
+
+	r.fieldsMu.Lock()
+	defer r.fieldsMu.Unlock()
+
+	if r.values == nil {
+		r.values = map[string]string{}
+	}
+	r.values[name] = val
+}
+
+// closingBrace returns the index of the brace closing a reference starting at i.
+// Nested references are allowed in default values (i.e. ${VAR:-${OTHER}}).
+func closingBrace(s string, i int) int {
+	depth := 1
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || ('0' <= c && c <= '9')
+}
+
+// stringValue returns the string representation of the value of a field, so it can be read again.
+func stringValue(v reflect.Value, listSep string) string {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return ""
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return ""
+		}
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		return stringValue(v.Elem(), listSep)
+
+	case reflect.Slice:
+		vals := make([]string, v.Len())
+		for i := range vals {
+			vals[i] = stringValue(v.Index(i), listSep)
+		}
+		return strings.Join(vals, listSep)
+	}
+
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	if v.CanAddr() {
+		if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	return fmt.Sprintf("%v", v.Interface())
+}
@@ -0,0 +1,199 @@
+package konfig
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/moorara/konfig/ptr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReaderExpandValue(t *testing.T) {
+	envs := map[string]string{
+		"EXPAND_HOST":  "localhost",
+		"EXPAND_PORT":  "5432",
+		"EXPAND_EMPTY": "",
+	}
+
+	for name, value := range envs {
+		err := os.Setenv(name, value)
+		assert.NoError(t, err)
+		defer os.Unsetenv(name)
+	}
+
+	r := &reader{
+		values: map[string]string{
+			"Host":   "db.example.com",
+			"URL":    "postgres://${.Host}:${EXPAND_PORT}",
+			"DSN":    "${.URL}/app",
+			"A":      "${.B}",
+			"B":      "${.C}",
+			"C":      "${.A}",
+			"Self":   "${.Self}",
+			"Empty":  "",
+			"Dollar": "$$",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		field         string
+		val           string
+		expectedValue string
+		expectedError error
+	}{
+		{"NoReference", "Field", "postgres://localhost:5432", "postgres://localhost:5432", nil},
+		{"Braces", "Field", "postgres://${EXPAND_HOST}:${EXPAND_PORT}", "postgres://localhost:5432", nil},
+		{"NoBraces", "Field", "postgres://$EXPAND_HOST:$EXPAND_PORT/db", "postgres://localhost:5432/db", nil},
+		{"Unset", "Field", "${EXPAND_UNSET}", "", nil},
+		{"Default", "Field", "${EXPAND_UNSET:-8080}", "8080", nil},
+		{"DefaultForEmpty", "Field", "${EXPAND_EMPTY:-8080}", "8080", nil},
+		{"DefaultNotUsed", "Field", "${EXPAND_PORT:-8080}", "5432", nil},
+		{"NestedDefault", "Field", "${EXPAND_UNSET:-${EXPAND_HOST}}", "localhost", nil},
+		{"Escape", "Field", "pa$$word", "pa$word", nil},
+		{"EscapedReference", "Field", "$${EXPAND_HOST}", "${EXPAND_HOST}", nil},
+		{"LoneDollar", "Field", "cost: 5$", "cost: 5$", nil},
+		{"DollarDigit", "Field", "$5", "$5", nil},
+		{"FieldReference", "Field", "${.Host}", "db.example.com", nil},
+		{"NestedFieldReference", "Field", "${.DSN}?sslmode=disable", "postgres://db.example.com:5432/app?sslmode=disable", nil},
+		{"FieldReferenceDefault", "Field", "${.Empty:-none}", "none", nil},
+		{"FieldWithEscape", "Field", "${.Dollar}", "$", nil},
+		{"UnknownField", "Field", "${.Unknown}", "", errors.New("unknown field in reference: Unknown")},
+		{"SelfReference", "Self", "${.Self}", "", errors.New("cycle in references: Self -> Self")},
+		{"Cycle", "A", "${.B}", "", errors.New("cycle in references: A -> B -> C -> A")},
+		{"MissingBrace", "Field", "${EXPAND_HOST", "", errors.New(`missing closing brace in "${EXPAND_HOST"`)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := r.expandValue(tc.field, tc.val)
+
+			assert.Equal(t, tc.expectedValue, val)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestStringValue(t *testing.T) {
+	u, _ := url.Parse("https://example.com")
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		listSep  string
+		expected string
+	}{
+		{"Nil", nil, ",", ""},
+		{"String", "content", ",", "content"},
+		{"Int", 27, ",", "27"},
+		{"Duration", 90 * time.Second, ",", "1m30s"},
+		{"URL", *u, ",", "https://example.com"},
+		{"NilPointer", (*int)(nil), ",", ""},
+		{"Pointer", ptr.Int(27), ",", "27"},
+		{"URLPointer", u, ",", "https://example.com"},
+		{"Slice", []int{1, 2, 3}, "|", "1|2|3"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := reflect.ValueOf(tc.value)
+			if tc.value != nil {
+				// Make the value addressable the same as struct fields
+				v = reflect.New(v.Type()).Elem()
+				v.Set(reflect.ValueOf(tc.value))
+			}
+
+			assert.Equal(t, tc.expected, stringValue(v, tc.listSep))
+		})
+	}
+}
+
+func TestPickExpand(t *testing.T) {
+	type expandConfig struct {
+		ExpandHost string
+		ExpandPort int
+		ExpandURL  url.URL
+		ExpandTags []string
+	}
+
+	envs := map[string]string{
+		"DB_HOST":     "db.example.com",
+		"EXPAND_HOST": "${DB_HOST}",
+		"EXPAND_URL":  "postgres://${.ExpandHost}:${.ExpandPort}/${DB_NAME:-app}",
+		"EXPAND_TAGS": "${.ExpandHost},$$literal",
+	}
+
+	for name, value := range envs {
+		err := os.Setenv(name, value)
+		assert.NoError(t, err)
+		defer os.Unsetenv(name)
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	t.Run("Disabled", func(t *testing.T) {
+		config := expandConfig{}
+		err := Pick(&config)
+
+		// References are not expanded, so the URL is not valid
+		assert.EqualError(t, err, `invalid values: ExpandURL from environment variable EXPAND_URL: parse "postgres://${.ExpandHost}:${.ExpandPort}/${DB_NAME:-app}": invalid port ":${.ExpandPort}" after host`)
+		assert.Equal(t, "${DB_HOST}", config.ExpandHost)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		config := expandConfig{
+			ExpandPort: 5432,
+		}
+		err := Pick(&config, Expand())
+
+		u, _ := url.Parse("postgres://db.example.com:5432/app")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expandConfig{
+			ExpandHost: "db.example.com",
+			ExpandPort: 5432,
+			ExpandURL:  *u,
+			ExpandTags: []string{"db.example.com", "$literal"},
+		}, config)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		err := os.Setenv("EXPAND_PORT", "${.ExpandURL}")
+		assert.NoError(t, err)
+		defer os.Unsetenv("EXPAND_PORT")
+
+		config := expandConfig{}
+		err = Pick(&config, Expand())
+
+		assert.Equal(t, errors.New("cycle in references: ExpandPort -> ExpandURL -> ExpandPort"), err)
+		assert.Equal(t, "db.example.com", config.ExpandHost)
+	})
+
+	t.Run("CycleAndInvalidValue", func(t *testing.T) {
+		err := os.Setenv("EXPAND_CYCLE", "${.ExpandCycle}")
+		assert.NoError(t, err)
+		defer os.Unsetenv("EXPAND_CYCLE")
+
+		err = os.Setenv("EXPAND_COUNT", "abc")
+		assert.NoError(t, err)
+		defer os.Unsetenv("EXPAND_COUNT")
+
+		config := struct {
+			ExpandCycle string
+			ExpandCount int
+		}{}
+		err = Pick(&config, Expand())
+
+		// Both errors are returned
+		assert.EqualError(t, err, "cycle in references: ExpandCycle -> ExpandCycle\n"+
+			`invalid values: ExpandCount from environment variable EXPAND_COUNT: strconv.ParseInt: parsing "abc": invalid syntax`)
+	})
+}
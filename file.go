@@ -0,0 +1,123 @@
+package konfig
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// TrimMode determines how the content of a file is trimmed before being used as a value.
+type TrimMode int
+
+const (
+	// TrimNewline removes a single trailing newline (\n or \r\n) from the content of a file.
+	// This is the default mode, so values written using echo or kubectl can be used as they are.
+	TrimNewline TrimMode = iota
+	// TrimSpace removes all leading and trailing white spaces from the content of a file.
+	TrimSpace
+	// TrimNone uses the content of a file as it is.
+	TrimNone
+)
+
+// String returns a human-readable name for a trim mode.
+func (m TrimMode) String() string {
+	switch m {
+	case TrimNewline:
+		return "Newline"
+	case TrimSpace:
+		return "Space"
+	case TrimNone:
+		return "None"
+	}
+
+	return fmt.Sprintf("TrimMode(%d)", int(m))
+}
+
+// parseTrimMode parses a trim mode from its name (i.e. newline, space, or none).
+func parseTrimMode(s string) (TrimMode, bool) {
+	switch strings.ToLower(s) {
+	case "newline":
+		return TrimNewline, true
+	case "space":
+		return TrimSpace, true
+	case "none":
+		return TrimNone, true
+	}
+
+	return TrimNewline, false
+}
+
+// trim trims the content of a file based on a trim mode.
+func (m TrimMode) trim(s string) string {
+	switch m {
+	case TrimNewline:
+		s = strings.TrimSuffix(s, "\n")
+		return strings.TrimSuffix(s, "\r")
+	case TrimSpace:
+		return strings.TrimSpace(s)
+	default:
+		return s
+	}
+}
+
+// readFile reads a file and returns its content as a value.
+// All values read from files (initially or when watching them) are read using this method,
+// so they are all trimmed the same way.
+// The contents of files for binary fields are never trimmed.
+func (r *reader) readFile(path string, binary bool) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return r.fileValue(b, binary), nil
+}
+
+// fileValue returns the content of a file as a value.
+func (r *reader) fileValue(b []byte, binary bool) string {
+	if binary {
+		return string(b)
+	}
+
+	return r.fileTrim.trim(string(b))
+}
+
+// isEncoding determines whether or not an encoding can be used in encoding tag.
+func isEncoding(encoding string) bool {
+	return encoding == "base64" || encoding == "base64url" || encoding == "hex"
+}
+
+// decodeValue decodes a value using an encoding specified by the encoding tag.
+// Supported encodings are base64, base64url, and hex.
+func decodeValue(encoding, val string) (string, error) {
+	var b []byte
+	var err error
+
+	switch encoding {
+	case "base64":
+		// Padding is optional
+		if strings.HasSuffix(val, "=") {
+			b, err = base64.StdEncoding.DecodeString(val)
+		} else {
+			b, err = base64.RawStdEncoding.DecodeString(val)
+		}
+	case "base64url":
+		if strings.HasSuffix(val, "=") {
+			b, err = base64.URLEncoding.DecodeString(val)
+		} else {
+			b, err = base64.RawURLEncoding.DecodeString(val)
+		}
+	case "hex":
+		b, err = hex.DecodeString(val)
+	default:
+		return "", fmt.Errorf("unknown encoding: %s", encoding)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value: %s", encoding, err)
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,187 @@
+package konfig
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimMode(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           TrimMode
+		s              string
+		expectedString string
+		expectedValue  string
+	}{
+		{"Newline", TrimNewline, " value \n\n", "Newline", " value \n"},
+		{"NewlineCRLF", TrimNewline, "value\r\n", "Newline", "value"},
+		{"NewlineNoNewline", TrimNewline, "value ", "Newline", "value "},
+		{"Space", TrimSpace, "\t value \r\n\n", "Space", "value"},
+		{"None", TrimNone, " value \n", "None", " value \n"},
+		{"Unknown", TrimMode(-1), " value \n", "TrimMode(-1)", " value \n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.mode.String())
+			assert.Equal(t, tc.expectedValue, tc.mode.trim(tc.s))
+		})
+	}
+}
+
+func TestParseTrimMode(t *testing.T) {
+	tests := []struct {
+		s            string
+		expectedMode TrimMode
+		expectedOK   bool
+	}{
+		{"newline", TrimNewline, true},
+		{"Space", TrimSpace, true},
+		{"NONE", TrimNone, true},
+		{"invalid", TrimNewline, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			mode, ok := parseTrimMode(tc.s)
+
+			assert.Equal(t, tc.expectedMode, mode)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestReaderReadFile(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString(" secret \n")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		r             *reader
+		path          string
+		binary        bool
+		expectedValue string
+		expectError   bool
+	}{
+		{"NoFile", &reader{}, "/tmp/missing", false, "", true},
+		{"Default", &reader{}, tmpfile.Name(), false, " secret ", false},
+		{"TrimSpace", &reader{fileTrim: TrimSpace}, tmpfile.Name(), false, "secret", false},
+		{"TrimNone", &reader{fileTrim: TrimNone}, tmpfile.Name(), false, " secret \n", false},
+		{"Binary", &reader{fileTrim: TrimSpace}, tmpfile.Name(), true, " secret \n", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := tc.r.readFile(tc.path, tc.binary)
+
+			assert.Equal(t, tc.expectedValue, val)
+			assert.Equal(t, tc.expectError, err != nil)
+		})
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		encoding      string
+		val           string
+		expectedValue string
+		expectedError error
+	}{
+		{"Base64", "base64", "c2VjcmV0Pz4+", "secret?>>", nil},
+		{"Base64NoPadding", "base64", "c2VjcmV0", "secret", nil},
+		{"Base64WithPadding", "base64", "c2VjcmV0MQ==", "secret1", nil},
+		{"Base64Invalid", "base64", "!secret!", "", errors.New("invalid base64 value: illegal base64 data at input byte 0")},
+		{"Base64URL", "base64url", "c2VjcmV0Pz4-", "secret?>>", nil},
+		{"Base64URLWithPadding", "base64url", "c2VjcmV0MQ==", "secret1", nil},
+		{"Hex", "hex", "736563726574", "secret", nil},
+		{"HexInvalid", "hex", "secret", "", errors.New("invalid hex value: encoding/hex: invalid byte: U+0073 's'")},
+		{"Unknown", "base32", "ONSWG4TFOQ======", "", errors.New("unknown encoding: base32")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := decodeValue(tc.encoding, tc.val)
+
+			assert.Equal(t, tc.expectedValue, val)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestReaderSetFieldValueWithEncoding(t *testing.T) {
+	type fields struct {
+		String string
+		Int    int
+		Slice  []string
+	}
+
+	tests := []struct {
+		name           string
+		fieldName      string
+		encoding       string
+		val            string
+		expectedError  string
+		expectedResult fields
+	}{
+		{"String", "String", "base64", "c2VjcmV0", "", fields{String: "secret"}},
+		{"Int", "Int", "hex", "3237", "", fields{Int: 27}},
+		{"Slice", "Slice", "base64", "Zm9vLGJhcg==", "", fields{Slice: []string{"foo", "bar"}}},
+		{"Invalid", "String", "hex", "secret", "invalid hex value: encoding/hex: invalid byte: U+0073 's'", fields{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := fields{}
+			f := fieldInfo{
+				value:    reflect.ValueOf(&s).Elem().FieldByName(tc.fieldName),
+				name:     tc.fieldName,
+				listSep:  ",",
+				encoding: tc.encoding,
+			}
+
+			_, err := new(reader).setFieldValue(f, tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedResult, s)
+		})
+	}
+}
+
+func TestPickUnknownEncoding(t *testing.T) {
+	type upstream struct {
+		Token string `encoding:"b32"`
+	}
+
+	type config struct {
+		Password  string `encoding:"b64"`
+		Upstreams []upstream
+	}
+
+	err := os.Setenv("PASSWORD", "c2VjcmV0")
+	assert.NoError(t, err)
+	defer os.Unsetenv("PASSWORD")
+
+	c := &config{}
+	err = Pick(c)
+
+	assert.EqualError(t, err, "invalid tags: Password: unknown encoding: b64; Upstreams[].Token: unknown encoding: b32")
+	assert.Equal(t, &config{}, c)
+}
@@ -0,0 +1,47 @@
+package konfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unmarshal unmarshals a value in a format specified by format tag into v.
+// Supported formats are json and yaml.
+func Unmarshal(format, val string, v interface{}) error {
+	switch format {
+	case "json":
+		return json.Unmarshal([]byte(val), v)
+	case "yaml":
+		return yaml.Unmarshal([]byte(val), v)
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+// isFormat determines whether or not a format can be used in format tag.
+func isFormat(format string) bool {
+	return format == "json" || format == "yaml"
+}
+
+// setFormatted unmarshals a value into a new value of the type of a field and sets it.
+// Fields that are not in the value will have their zero values.
+func (r *reader) setFormatted(f fieldInfo, val string) (bool, error) {
+	p := reflect.New(f.value.Type())
+
+	if err := Unmarshal(f.format, val, p.Interface()); err != nil {
+		return false, fmt.Errorf("invalid %s value: %s", f.format, err)
+	}
+
+	if reflect.DeepEqual(f.value.Interface(), p.Elem().Interface()) {
+		return false, nil
+	}
+
+	r.log(5, fmt.Sprintf("setting %s value", f.format), "field", f.name, "value", p.Elem().Interface())
+	f.value.Set(p.Elem())
+	r.notifySubscribers(f.name, p.Elem().Interface())
+
+	return true, nil
+}
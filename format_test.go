@@ -0,0 +1,257 @@
+package konfig
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         string
+		val            string
+		expectedResult map[string][]int
+		expectedError  bool
+	}{
+		{"JSON", "json", `{"a": [1, 2]}`, map[string][]int{"a": {1, 2}}, false},
+		{"YAML", "yaml", "a: [1, 2]\nb:\n  - 3\n", map[string][]int{"a": {1, 2}, "b": {3}}, false},
+		{"InvalidJSON", "json", `{"a": `, nil, true},
+		{"InvalidYAML", "yaml", "a: [1", nil, true},
+		{"UnknownFormat", "toml", `a = [1, 2]`, nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var m map[string][]int
+			err := Unmarshal(tc.format, tc.val, &m)
+
+			assert.Equal(t, tc.expectedResult, m)
+			assert.Equal(t, tc.expectedError, err != nil)
+		})
+	}
+
+	assert.Equal(t, errors.New("unknown format: toml"), Unmarshal("toml", "", nil))
+}
+
+func TestReaderSetFormatted(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+
+	type fields struct {
+		Labels  map[string]string
+		Server  server
+		Servers []*server
+		Matrix  [][]int
+	}
+
+	tests := []struct {
+		name            string
+		s               fields
+		field           string
+		format          string
+		val             string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  fields
+	}{
+		{
+			name:            "Map",
+			field:           "Labels",
+			format:          "json",
+			val:             `{"team": "platform"}`,
+			expectedUpdated: true,
+			expectedResult:  fields{Labels: map[string]string{"team": "platform"}},
+		},
+		{
+			name:            "Struct",
+			s:               fields{Server: server{Host: "localhost", Port: 8080}},
+			field:           "Server",
+			format:          "yaml",
+			val:             "host: example.com",
+			expectedUpdated: true,
+			expectedResult:  fields{Server: server{Host: "example.com"}},
+		},
+		{
+			name:            "Pointers",
+			field:           "Servers",
+			format:          "yaml",
+			val:             "- host: a.example.com\n- port: 80\n",
+			expectedUpdated: true,
+			expectedResult:  fields{Servers: []*server{{Host: "a.example.com"}, {Port: 80}}},
+		},
+		{
+			name:            "NoChange",
+			s:               fields{Matrix: [][]int{{1, 2}}},
+			field:           "Matrix",
+			format:          "json",
+			val:             `[[1, 2]]`,
+			expectedUpdated: false,
+			expectedResult:  fields{Matrix: [][]int{{1, 2}}},
+		},
+		{
+			name:           "InvalidValue",
+			field:          "Matrix",
+			format:         "json",
+			val:            `[[1, 2]`,
+			expectedError:  "invalid json value: unexpected end of JSON input",
+			expectedResult: fields{},
+		},
+		{
+			name:           "InvalidYAMLValue",
+			field:          "Labels",
+			format:         "yaml",
+			val:            "[a",
+			expectedError:  "invalid yaml value: yaml: line 1: did not find expected ',' or ']'",
+			expectedResult: fields{},
+		},
+		{
+			name:           "UnknownFormat",
+			field:          "Labels",
+			format:         "toml",
+			val:            `team = "platform"`,
+			expectedError:  "invalid toml value: unknown format: toml",
+			expectedResult: fields{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := fieldInfo{
+				value:  reflect.ValueOf(&tc.s).Elem().FieldByName(tc.field),
+				name:   tc.field,
+				format: tc.format,
+			}
+
+			updated, err := new(reader).setFieldValue(f, tc.val)
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+			assert.Equal(t, tc.expectedResult, tc.s)
+		})
+	}
+}
+
+func TestPickFormatted(t *testing.T) {
+	type limits struct {
+		RPS   int `json:"rps" yaml:"rps"`
+		Burst int `json:"burst" yaml:"burst"`
+	}
+
+	type config struct {
+		FormatRoutes map[string][]string `format:"json"`
+		FormatLimits limits              `format:"yaml"`
+		FormatTokens []byte              `format:"json"`
+		FormatPlain  map[string]string
+	}
+
+	err := os.Setenv("FORMAT_ROUTES", `{"/api": ["a", "b"]}`)
+	assert.NoError(t, err)
+	defer os.Unsetenv("FORMAT_ROUTES")
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("rps: 100\nburst: 20\n")
+	assert.NoError(t, err)
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	err = os.Setenv("FORMAT_LIMITS_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("FORMAT_LIMITS_FILE")
+
+	// A JSON string is base64-decoded into a []byte
+	err = os.Setenv("FORMAT_TOKENS", `"AAE="`)
+	assert.NoError(t, err)
+	defer os.Unsetenv("FORMAT_TOKENS")
+
+	err = os.Setenv("FORMAT_PLAIN", `{"a": "b"}`)
+	assert.NoError(t, err)
+	defer os.Unsetenv("FORMAT_PLAIN")
+
+	c := &config{}
+	err = Pick(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &config{
+		FormatRoutes: map[string][]string{"/api": {"a", "b"}},
+		FormatLimits: limits{RPS: 100, Burst: 20},
+		FormatTokens: []byte{0, 1},
+	}, c)
+}
+
+func TestPickFormattedInvalid(t *testing.T) {
+	type route struct {
+		Path string `json:"path"`
+	}
+
+	type upstream struct {
+		Host   string
+		Labels map[string]string `format:"xml"`
+	}
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		type config struct {
+			Routes []route `format:"json"`
+		}
+
+		err := os.Setenv("ROUTES", `{bad json`)
+		assert.NoError(t, err)
+		defer os.Unsetenv("ROUTES")
+
+		c := &config{}
+		err = Pick(c)
+
+		assert.EqualError(t, err, "invalid values: Routes from environment variable ROUTES: invalid json value: invalid character 'b' looking for beginning of object key string")
+		assert.Equal(t, &config{}, c)
+	})
+
+	t.Run("InvalidValueFromFile", func(t *testing.T) {
+		type config struct {
+			Routes []route `format:"json"`
+		}
+
+		tmpfile, err := ioutil.TempFile("", "gotest_")
+		assert.NoError(t, err)
+		defer os.Remove(tmpfile.Name())
+
+		_, err = tmpfile.WriteString(`{bad json`)
+		assert.NoError(t, err)
+		err = tmpfile.Close()
+		assert.NoError(t, err)
+
+		err = os.Setenv("ROUTES_FILE", tmpfile.Name())
+		assert.NoError(t, err)
+		defer os.Unsetenv("ROUTES_FILE")
+
+		c := &config{}
+		err = Pick(c)
+
+		assert.EqualError(t, err, "invalid values: Routes from file "+tmpfile.Name()+": invalid json value: invalid character 'b' looking for beginning of object key string")
+		assert.Equal(t, &config{}, c)
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		type config struct {
+			Routes    map[string]string `format:"jsn"`
+			Upstreams []upstream
+		}
+
+		c := &config{}
+		err := Pick(c)
+
+		assert.EqualError(t, err, "invalid tags: Routes: unknown format: jsn; Upstreams[].Labels: unknown format: xml")
+	})
+}
@@ -1,8 +1,15 @@
 module github.com/moorara/konfig
 
-go 1.15
+go 1.21
 
 require (
 	github.com/fsnotify/fsnotify v1.4.9
 	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.0.0-20191005200804-aed5e4c7ecf9 // indirect
 )
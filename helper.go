@@ -21,104 +21,170 @@ func (v flagValue) Set(string) error {
 }
 
 // tokenize breaks a field name into its tokens (generally words).
-//   UserID       -->  User, ID
-//   DatabaseURL  -->  Database, URL
-func tokenize(name string) []string {
+//   - A word starts with an upper-case letter after a lower-case letter (i.e. LogLevel --> Log, Level).
+//   - The last upper-case letter before a lower-case letter starts a word (i.e. HTTPServer --> HTTP, Server).
+//   - Digits belong to the word before them (i.e. S3Bucket --> S3, Bucket).
+//   - Lower-case letters after digits belong to the same word (i.e. K8sNamespace --> K8s, Namespace).
+//   - A plural s after upper-case letters belongs to them (i.e. ServerIPs --> Server, IPs).
+//   - Characters other than letters and digits (i.e. underscores) separate words and are removed.
+//   - Acronyms are kept as one word wherever they are if they are not followed by a lower-case letter (i.e. ServergRPCPort --> Server, gRPC, Port).
+//
+// Acronyms are matched in the given order, so longer acronyms should come first.
+//
+//	UserID          -->  User, ID
+//	DatabaseURL     -->  Database, URL
+//	OAuth2ClientID  -->  OAuth2, Client, ID
+func tokenize(name string, acronyms []string) []string {
+	runes := []rune(name)
 	tokens := []string{}
-	current := string(name[0])
-	lastLower := unicode.IsLower(rune(name[0]))
+	current := []rune{}
+	closed := false // whether or not the current word cannot have more letters
 
-	add := func(slice []string, str string) []string {
-		if str == "" {
-			return slice
+	add := func() {
+		if len(current) > 0 {
+			tokens = append(tokens, string(current))
 		}
-		return append(slice, str)
+		current, closed = []rune{}, false
 	}
 
-	for i := 1; i < len(name); i++ {
-		r := rune(name[i])
-
-		if unicode.IsUpper(r) && lastLower {
-			// The case is changing from lower to upper
-			tokens = add(tokens, current)
-			current = string(name[i])
-		} else if unicode.IsLower(r) && !lastLower {
-			// The case is changing from upper to lower
-			l := len(current) - 1
-			tokens = add(tokens, current[:l])
-			current = current[l:] + string(name[i])
-		} else {
-			// Increment current token
-			current += string(name[i])
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		// Separators
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			add()
+			continue
+		}
+
+		// Digits
+		if unicode.IsDigit(r) {
+			current = append(current, r)
+			closed = true
+			continue
+		}
+
+		// Acronyms
+		if a := matchAcronym(runes[i:], acronyms); a != nil {
+			add()
+			current, closed = a, true
+			i += len(a) - 1
+			continue
+		}
+
+		var last rune
+		if len(current) > 0 {
+			last = current[len(current)-1]
 		}
 
-		lastLower = unicode.IsLower(r)
+		switch {
+		case len(current) == 0:
+			current = append(current, r)
+		case unicode.IsDigit(last) && unicode.IsLower(r):
+			// Lower-case letters after digits belong to the same word
+			current = append(current, r)
+			closed = false
+		case r == 's' && isUpperRun(current) && !isLowerAt(runes, i+1):
+			// A plural s after upper-case letters belongs to them and ends the word
+			current = append(current, r)
+			closed = true
+		case closed, unicode.IsUpper(r) && unicode.IsLower(last):
+			// A new word starts after digits and acronyms and when the case is changing from lower to upper
+			add()
+			current = append(current, r)
+		case unicode.IsLower(r) && unicode.IsUpper(last) && len(current) > 1:
+			// The case is changing from upper to lower, so the last upper-case letter starts a new word
+			current = current[:len(current)-1]
+			add()
+			current = append(current, last, r)
+		default:
+			current = append(current, r)
+		}
 	}
 
-	tokens = append(tokens, string(current))
+	add()
 
 	return tokens
 }
 
-// getFlagName returns a canonical flag name for a field.
-//   UserID       -->  user.id
-//   DatabaseURL  -->  database.url
-func getFlagName(name string) string {
-	parts := tokenize(name)
-	result := strings.Join(parts, ".")
-	result = strings.ToLower(result)
+// isUpperRun determines whether or not a word has more than one letter and all of its letters are upper-case (i.e. IP but not I or Ip).
+func isUpperRun(word []rune) bool {
+	if len(word) < 2 {
+		return false
+	}
 
-	return result
-}
+	for _, r := range word {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
 
-// getFlagName returns a canonical environment variable name for a field.
-//   UserID       -->  USER_ID
-//   DatabaseURL  -->  DATABASE_URL
-func getEnvVarName(name string) string {
-	parts := tokenize(name)
-	result := strings.Join(parts, "_")
-	result = strings.ToUpper(result)
+	return true
+}
 
-	return result
+// isLowerAt determines whether or not there is a lower-case letter at an index.
+func isLowerAt(runes []rune, i int) bool {
+	return i < len(runes) && unicode.IsLower(runes[i])
 }
 
-// getFileVarName returns a canonical environment variable name for value file of a field.
-//   UserID       -->  USER_ID_FILE
-//   DatabaseURL  -->  DATABASE_URL_FILE
-func getFileEnvVarName(name string) string {
-	parts := tokenize(name)
-	result := strings.Join(parts, "_")
-	result = strings.ToUpper(result)
-	result = result + "_FILE"
+// matchAcronym returns the first acronym that the given runes start with if it is not followed by a lower-case letter.
+func matchAcronym(runes []rune, acronyms []string) []rune {
+	for _, acronym := range acronyms {
+		a := []rune(acronym)
+		if len(a) == 0 || len(a) > len(runes) || string(runes[:len(a)]) != acronym {
+			continue
+		}
+
+		if isLowerAt(runes, len(a)) {
+			continue
+		}
 
-	return result
+		return a
+	}
+
+	return nil
 }
 
-// getFlagValue returns the value set for a flag.
+// genericFlagRegex matches any argument that looks like a flag.
+var genericFlagRegex = regexp.MustCompile("^-{1,2}[A-Za-z].*")
+
+// flagArgs maps the names of flags to their values.
+type flagArgs map[string]string
+
+// parseFlagArgs parses command-line arguments for flags and their values.
 //   - The flag name can start with - or --
 //   - The flag value can be separated by space or =
-func getFlagValue(flagName string) string {
-	flagRegex := regexp.MustCompile("-{1,2}" + flagName)
-	genericRegex := regexp.MustCompile("^-{1,2}[A-Za-z].*")
-
-	for i, arg := range os.Args {
-		if flagRegex.MatchString(arg) {
-			if s := strings.Index(arg, "="); s > 0 {
-				return arg[s+1:]
-			}
-
-			if i+1 < len(os.Args) {
-				val := os.Args[i+1]
-				if !genericRegex.MatchString(val) {
-					return val
-				}
-			}
-
-			return "true"
+//   - A flag without a value is set to true
+// If a flag is repeated, the first value is used.
+func parseFlagArgs(args []string) flagArgs {
+	flags := flagArgs{}
+
+	for i, arg := range args {
+		if !genericFlagRegex.MatchString(arg) {
+			continue
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		val := "true"
+
+		if s := strings.Index(name, "="); s > 0 {
+			name, val = name[:s], name[s+1:]
+		} else if i+1 < len(args) && !genericFlagRegex.MatchString(args[i+1]) {
+			val = args[i+1]
+		}
+
+		if _, ok := flags[name]; !ok {
+			flags[name] = val
 		}
 	}
 
-	return ""
+	return flags
+}
+
+// getFlagValue returns the value set for a flag.
+//   - The flag name can start with - or --
+//   - The flag value can be separated by space or =
+func getFlagValue(flagName string) string {
+	return parseFlagArgs(os.Args)[flagName]
 }
 
 func validateStruct(s interface{}) (reflect.Value, error) {
@@ -141,7 +207,35 @@ func validateStruct(s interface{}) (reflect.Value, error) {
 	return v, nil
 }
 
+// isNamedBasic determines whether or not a type is declared in a package with a basic underlying type (i.e. type Level int).
+// Such types are read the same as their underlying types, but pointers to them and lists of them are not supported.
+// time.Duration and the types parsed from strings (i.e. konfig.ByteSize) are not considered named basic types.
+func isNamedBasic(t reflect.Type) bool {
+	if t.PkgPath() == "" || (t.PkgPath() == "time" && t.Name() == "Duration") {
+		return false
+	}
+
+	if _, ok := textTypes[t]; ok {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
 func isTypeSupported(t reflect.Type) bool {
+	if _, ok := textTypes[t]; ok {
+		return true
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		return true
@@ -149,15 +243,27 @@ func isTypeSupported(t reflect.Type) bool {
 		return true
 	case reflect.Float32, reflect.Float64:
 		return true
+	case reflect.Complex64, reflect.Complex128:
+		return true
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return true
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return true
-	case reflect.Ptr, reflect.Slice:
-		return isTypeSupported(t.Elem())
+	case reflect.Ptr:
+		return !isNamedBasic(t.Elem()) && isTypeSupported(t.Elem())
+	case reflect.Slice:
+		// Lists of TLS types and named basic types are not supported
+		return isStructList(t) || (!isTLSType(t.Elem()) && !isNamedBasic(t.Elem()) && isTypeSupported(t.Elem()))
+	case reflect.Array:
+		// Arrays are read the same as slices, except lists of structs and nested lists (i.e. [2][]int)
+		s := reflect.SliceOf(t.Elem())
+		_, text := textTypes[t.Elem()]
+		nested := t.Elem().Kind() == reflect.Slice || t.Elem().Kind() == reflect.Array
+		return (text || !nested) && !isStructList(s) && isTypeSupported(s)
 	case reflect.Struct:
 		return (t.PkgPath() == "net/url" && t.Name() == "URL") ||
-			(t.PkgPath() == "regexp" && t.Name() == "Regexp")
+			(t.PkgPath() == "regexp" && t.Name() == "Regexp") ||
+			isTLSType(t)
 	}
 
 	return false
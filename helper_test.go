@@ -1,7 +1,11 @@
 package konfig
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"net"
+	"net/netip"
 	"net/url"
 	"os"
 	"reflect"
@@ -23,88 +27,75 @@ func TestFlagValue(t *testing.T) {
 func TestTokenize(t *testing.T) {
 	tests := []struct {
 		fieldName      string
+		acronyms       []string
 		expectedTokens []string
 	}{
-		{"c", []string{"c"}},
-		{"C", []string{"C"}},
-		{"camel", []string{"camel"}},
-		{"Camel", []string{"Camel"}},
-		{"camelCase", []string{"camel", "Case"}},
-		{"CamelCase", []string{"Camel", "Case"}},
-		{"OneTwoThree", []string{"One", "Two", "Three"}},
-		{"DatabaseURL", []string{"Database", "URL"}},
-		{"DBEndpoints", []string{"DB", "Endpoints"}},
+		{"c", nil, []string{"c"}},
+		{"C", nil, []string{"C"}},
+		{"camel", nil, []string{"camel"}},
+		{"Camel", nil, []string{"Camel"}},
+		{"camelCase", nil, []string{"camel", "Case"}},
+		{"CamelCase", nil, []string{"Camel", "Case"}},
+		{"OneTwoThree", nil, []string{"One", "Two", "Three"}},
+		{"DatabaseURL", nil, []string{"Database", "URL"}},
+		{"DBEndpoints", nil, []string{"DB", "Endpoints"}},
+		{"HTTPServer", nil, []string{"HTTP", "Server"}},
+		{"S3Bucket", nil, []string{"S3", "Bucket"}},
+		{"HTTP2Enabled", nil, []string{"HTTP2", "Enabled"}},
+		{"Base64Encoded", nil, []string{"Base64", "Encoded"}},
+		{"Retry3x", nil, []string{"Retry3x"}},
+		{"K8sNamespace", nil, []string{"K8s", "Namespace"}},
+		{"V2API", nil, []string{"V2", "API"}},
+		{"V2", nil, []string{"V2"}},
+		{"Log_Level", nil, []string{"Log", "Level"}},
+		{"OAuth2ClientID", nil, []string{"O", "Auth2", "Client", "ID"}},
+		{"OAuth2ClientID", []string{"OAuth"}, []string{"OAuth2", "Client", "ID"}},
+		{"GitHubOAuthToken", []string{"OAuth"}, []string{"Git", "Hub", "OAuth", "Token"}},
+		{"GitHubOAuthToken", []string{"GitHub", "OAuth"}, []string{"GitHub", "OAuth", "Token"}},
+		{"Oauthority", []string{"OAuth"}, []string{"Oauthority"}},
+		{"OAuthor", []string{"OAuth"}, []string{"O", "Author"}},
+		{"UsegRPC", []string{"gRPC"}, []string{"Use", "gRPC"}},
+		{"ServergRPCPort", []string{"gRPC"}, []string{"Server", "gRPC", "Port"}},
+		{"HTTPOAuthToken", []string{"OAuth"}, []string{"HTTP", "OAuth", "Token"}},
+		{"ListenIPv6", []string{"IPv6", "IP"}, []string{"Listen", "IPv6"}},
+		{"UserIDs", nil, []string{"User", "IDs"}},
+		{"ServerIPs", nil, []string{"Server", "IPs"}},
+		{"UUIDs", nil, []string{"UUIDs"}},
+		{"APIsEnabled", nil, []string{"APIs", "Enabled"}},
+		{"DBsize", nil, []string{"D", "Bsize"}},
+		{"ÜberCount", nil, []string{"Über", "Count"}},
+		{"NameÄnderung", nil, []string{"Name", "Änderung"}},
+		{"ΑλφαΒήτα", nil, []string{"Αλφα", "Βήτα"}},
+		{"X名前", nil, []string{"X名前"}},
 	}
 
 	for _, tc := range tests {
-		tokens := tokenize(tc.fieldName)
+		tokens := tokenize(tc.fieldName, tc.acronyms)
 		assert.Equal(t, tc.expectedTokens, tokens)
 	}
 }
 
-func TestGetFlagName(t *testing.T) {
+func TestMatchAcronym(t *testing.T) {
 	tests := []struct {
-		fieldName        string
-		expectedFlagName string
+		s        string
+		acronyms []string
+		expected string
 	}{
-		{"c", "c"},
-		{"C", "c"},
-		{"camel", "camel"},
-		{"Camel", "camel"},
-		{"camelCase", "camel.case"},
-		{"CamelCase", "camel.case"},
-		{"OneTwoThree", "one.two.three"},
-		{"DatabaseURL", "database.url"},
-		{"DBEndpoints", "db.endpoints"},
+		{"OAuthToken", []string{"OAuth"}, "OAuth"},
+		{"OAuth", []string{"OAuth"}, "OAuth"},
+		{"OAuth2", []string{"OAuth"}, "OAuth"},
+		{"OAuthor", []string{"OAuth"}, ""},
+		{"OAut", []string{"OAuth"}, ""},
+		{"IPv6Address", []string{"IP"}, ""},
+		{"IPAddress", []string{"IP", "IPv6"}, "IP"},
+		{"IPv6Address", []string{"IPv6", "IP"}, "IPv6"},
+		{"Token", []string{"", "OAuth"}, ""},
 	}
 
 	for _, tc := range tests {
-		flagName := getFlagName(tc.fieldName)
-		assert.Equal(t, tc.expectedFlagName, flagName)
-	}
-}
-
-func TestGetEnvVarName(t *testing.T) {
-	tests := []struct {
-		fieldName          string
-		expectedEnvVarName string
-	}{
-		{"c", "C"},
-		{"C", "C"},
-		{"camel", "CAMEL"},
-		{"Camel", "CAMEL"},
-		{"camelCase", "CAMEL_CASE"},
-		{"CamelCase", "CAMEL_CASE"},
-		{"OneTwoThree", "ONE_TWO_THREE"},
-		{"DatabaseURL", "DATABASE_URL"},
-		{"DBEndpoints", "DB_ENDPOINTS"},
-	}
-
-	for _, tc := range tests {
-		envVarName := getEnvVarName(tc.fieldName)
-		assert.Equal(t, tc.expectedEnvVarName, envVarName)
-	}
-}
-
-func TestGetFileEnvVarName(t *testing.T) {
-	tests := []struct {
-		fieldName              string
-		expectedFileEnvVarName string
-	}{
-		{"c", "C_FILE"},
-		{"C", "C_FILE"},
-		{"camel", "CAMEL_FILE"},
-		{"Camel", "CAMEL_FILE"},
-		{"camelCase", "CAMEL_CASE_FILE"},
-		{"CamelCase", "CAMEL_CASE_FILE"},
-		{"OneTwoThree", "ONE_TWO_THREE_FILE"},
-		{"DatabaseURL", "DATABASE_URL_FILE"},
-		{"DBEndpoints", "DB_ENDPOINTS_FILE"},
-	}
-
-	for _, tc := range tests {
-		fileEnvVarName := getFileEnvVarName(tc.fieldName)
-		assert.Equal(t, tc.expectedFileEnvVarName, fileEnvVarName)
+		t.Run(tc.s, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(matchAcronym([]rune(tc.s), tc.acronyms)))
+		})
 	}
 }
 
@@ -157,6 +148,60 @@ func TestGetFlagValue(t *testing.T) {
 	}
 }
 
+func TestParseFlagArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected flagArgs
+	}{
+		{
+			"NoFlag",
+			[]string{"app", "invalid"},
+			flagArgs{},
+		},
+		{
+			"Flags",
+			[]string{"app", "-enabled", "--number", "-10", "-text=content", "--name-list", "alice,bob"},
+			flagArgs{
+				"enabled":   "true",
+				"number":    "-10",
+				"text":      "content",
+				"name-list": "alice,bob",
+			},
+		},
+		{
+			"SimilarNames",
+			[]string{"app", "-log.level=debug", "--log", "json"},
+			flagArgs{
+				"log.level": "debug",
+				"log":       "json",
+			},
+		},
+		{
+			"RepeatedFlags",
+			[]string{"app", "-text=foo", "-text=bar"},
+			flagArgs{
+				"text": "foo",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			flags := parseFlagArgs(tc.args)
+			assert.Equal(t, tc.expected, flags)
+		})
+	}
+}
+
+func BenchmarkParseFlagArgs(b *testing.B) {
+	args := []string{"app", "-enabled", "--number", "-10", "-text=content", "--name-list", "alice,bob"}
+
+	for i := 0; i < b.N; i++ {
+		parseFlagArgs(args)
+	}
+}
+
 func TestValidateStruct(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -221,6 +266,10 @@ func TestIsTypeSupported(t *testing.T) {
 		{"URL", *u, true},
 		{"Regexp", *r, true},
 		{"Duration", time.Second, true},
+		{"Named", level(1), true},
+		{"NamedPointer", new(level), false},
+		{"NamedSlice", []level{}, false},
+		{"NamedArray", [2]level{}, false},
 		{"StringPointer", ptr.String("content"), true},
 		{"BoolPointer", ptr.Bool(true), true},
 		{"Float32Pointer", ptr.Float32(3.1415), true},
@@ -254,7 +303,37 @@ func TestIsTypeSupported(t *testing.T) {
 		{"Uint64Slice", []uint64{18446744073709551615}, true},
 		{"URLSlice", []url.URL{*u}, true},
 		{"RegexpSlice", []regexp.Regexp{*r}, true},
+		{"Certificate", tls.Certificate{}, true},
+		{"CertificatePtr", &tls.Certificate{}, true},
+		{"CertificateSlice", []tls.Certificate{}, false},
+		{"CertPool", x509.CertPool{}, true},
+		{"CertPoolPtr", x509.NewCertPool(), true},
+		{"CertPoolSlice", []*x509.CertPool{}, false},
+		{"IP", net.IP{}, true},
+		{"IPNetPtr", &net.IPNet{}, true},
+		{"AddrSlice", []netip.Addr{}, true},
+		{"PrefixPtr", &netip.Prefix{}, true},
+		{"AddrPort", netip.AddrPort{}, true},
+		{"Time", time.Time{}, true},
+		{"TimeSlice", []time.Time{}, true},
+		{"Location", time.UTC, true},
+		{"LocationSlice", []*time.Location{}, true},
+		{"TimeOfDayPtr", &TimeOfDay{}, true},
+		{"ByteSizeSlice", []ByteSize{}, true},
+		{"QuantityPtr", new(Quantity), true},
+		{"StructSlice", []struct{ Host string }{}, true},
+		{"EmptyStructSlice", []struct{}{}, false},
 		{"DurationSlice", []time.Duration{time.Second}, true},
+		{"Complex64", complex64(1 + 2i), true},
+		{"Complex128Ptr", new(complex128), true},
+		{"Complex128Slice", []complex128{1 + 2i}, true},
+		{"Float64Array", [3]float64{}, true},
+		{"IPArray", [2]net.IP{}, true},
+		{"CertificateArray", [1]tls.Certificate{}, false},
+		{"StructArray", [2]struct{ Host string }{}, false},
+		{"ChanArray", [2]chan int{}, false},
+		{"NestedArray", [2][3]int{}, false},
+		{"SliceArray", [2][]int{}, false},
 	}
 
 	for _, tc := range tests {
@@ -2,21 +2,20 @@
 // based on The 12-Factor App (https://12factor.net/config).
 package konfig
 
-import (
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"sync"
-
-	"github.com/fsnotify/fsnotify"
-)
+import "sync"
 
 const (
-	skip       = "-"
-	tagFlag    = "flag"
-	tagEnv     = "env"
-	tagFileEnv = "fileenv"
-	tagSep     = "sep"
+	skip          = "-"
+	tagFlag       = "flag"
+	tagEnv        = "env"
+	tagFileEnv    = "fileenv"
+	tagSep        = "sep"
+	tagEncoding   = "encoding"
+	tagLayout     = "layout"
+	tagUnit       = "unit"
+	tagFormat     = "format"
+	tagKonfig     = "konfig"
+	tagDeprecated = "deprecated"
 
 	envDebug            = "KONFIG_DEBUG"
 	envListSep          = "KONFIG_LIST_SEP"
@@ -26,8 +25,16 @@ const (
 	envPrefixFlag       = "KONFIG_PREFIX_FLAG"
 	envPrefixEnv        = "KONFIG_PREFIX_ENV"
 	envPrefixFileEnv    = "KONFIG_PREFIX_FILE_ENV"
+	envFlagNaming       = "KONFIG_FLAG_NAMING"
+	envEnvNaming        = "KONFIG_ENV_NAMING"
+	envFileEnvNaming    = "KONFIG_FILE_ENV_NAMING"
+	envAcronyms         = "KONFIG_ACRONYMS"
 	envTelepresence     = "KONFIG_TELEPRESENCE"
 	envTelepresenceRoot = "TELEPRESENCE_ROOT"
+	envPollInterval     = "KONFIG_POLL_INTERVAL"
+	envExpand           = "KONFIG_EXPAND"
+	envFileTrim         = "KONFIG_FILE_TRIM"
+	envStrict           = "KONFIG_STRICT"
 
 	line = "----------------------------------------------------------------------------------------------------"
 )
@@ -42,125 +49,29 @@ type Update struct {
 // Default values can also be specified.
 // You should pass the pointer to a struct for config; otherwise you will get an error.
 func Pick(config interface{}, opts ...Option) error {
-	c := readerFromEnv()
-	for _, opt := range opts {
-		opt(c)
-	}
-
-	c.log(2, line)
-	c.log(2, "Options: %s", c)
-	c.log(2, line)
-
-	v, err := validateStruct(config)
-	if err != nil {
-		c.log(1, err.Error())
-		return err
-	}
+	return NewLoader(opts...).Pick(config)
+}
 
-	c.registerFlags(v)
-	c.readFields(v)
+// Load is the generic version of Pick.
+// It reads values for exported fields of a struct type from either command-line flags, environment variables, or configuration files,
+// and returns a populated struct.
+// Fields without any value will have their zero values.
+func Load[T any](opts ...Option) (T, error) {
+	var config T
+	err := Pick(&config, opts...)
 
-	return nil
+	return config, err
 }
 
 // Watch first reads values for exported fields of a struct from either command-line flags, environment variables, or configuration files.
 // It then watches any change to those fields that their values are read from configuration files and notifies subscribers on a channel.
+// The returned function stops watching. Files can still be reloaded on signals using ReloadOnSignal option,
+// but for reloading files on demand, you should use a Loader (or NewLive) and call its Reload method instead.
 func Watch(config sync.Locker, subscribers []chan Update, opts ...Option) (func(), error) {
-	c := readerFromEnv()
-	c.subscribers = subscribers
-	for _, opt := range opts {
-		opt(c)
-	}
-
-	c.log(2, line)
-	c.log(2, "Options: %s", c)
-	c.log(2, line)
-
-	v, err := validateStruct(config)
-	if err != nil {
-		c.log(1, err.Error())
+	l := NewLoader(opts...)
+	if err := l.Watch(config, subscribers); err != nil {
 		return nil, err
 	}
 
-	c.registerFlags(v)
-	c.readFields(v)
-
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		c.log(1, "cannot create a watcher: %s", err)
-		return nil, err
-	}
-
-	go func() {
-		for {
-			select {
-			case event, ok := <-watcher.Events:
-				if !ok {
-					break
-				}
-
-				path := filepath.Clean(event.Name)
-				c.log(6, "event received: %s %s", event.Op, event.Name)
-
-				// We only receive events for added files, this if check is redundant!
-				if f, ok := c.filesToFields[path]; ok {
-					// Write
-					if event.Op&fsnotify.Write == fsnotify.Write {
-						if b, err := ioutil.ReadFile(path); err == nil {
-							val := string(b)
-							c.log(3, "received an update from %s: %s", path, val)
-							config.Lock()
-							c.setFieldValue(f, val)
-							config.Unlock()
-						}
-					}
-
-					// Remove
-					// Kubernetes injects new values from ConfigMaps and Secrets by removing the mounted files and recreating them.
-					// When a watched file is removed, the fsnotify package will remove it from the watcher too.
-					// This if block is a workaround for the aforementioned Kubernetes situation.
-					// See https://github.com/moorara/konfig/issues/47
-					if event.Op&fsnotify.Remove == fsnotify.Remove {
-						// Check if the removed file is already recreated
-						if _, err := os.Stat(path); err == nil {
-							if b, err := ioutil.ReadFile(path); err == nil {
-								val := string(b)
-								c.log(3, "received an update from %s: %s", path, val)
-								config.Lock()
-								c.setFieldValue(f, val)
-								config.Unlock()
-							}
-
-							// Re-Add a watch for the file
-							if err := watcher.Add(path); err != nil {
-								c.log(1, "cannot watch file %s: %s", f, err)
-							}
-						}
-					}
-				}
-			case err, ok := <-watcher.Errors:
-				if !ok {
-					break
-				}
-				c.log(1, "error watching: %s", err)
-			}
-		}
-	}()
-
-	for path := range c.filesToFields {
-		if err := watcher.Add(path); err != nil {
-			c.log(1, "cannot watch file %s: %s", path, err)
-			return nil, err
-		}
-	}
-
-	close := func() {
-		watcher.Close()
-		// TODO: closing subscriber channels causes data race if notifySubscribers is writing to any
-		/* for _, sub := range c.subscribers {
-			close(sub)
-		} */
-	}
-
-	return close, nil
+	return l.Close, nil
 }
@@ -0,0 +1,39 @@
+// Package konfigtest provides helpers for testing the code generated by konfig-gen.
+package konfigtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/moorara/konfig"
+)
+
+// LoadFunc is the signature of the functions generated by konfig-gen.
+type LoadFunc[T any] func(config *T, opts ...konfig.Option) error
+
+// Agree verifies that a generated loader reads the same values and returns the same error as konfig.Pick
+// for the current command-line flags, environment variables, and configuration files.
+// newConfig should return a new instance of the configuration struct with default values every time it is called.
+func Agree[T any](t testing.TB, newConfig func() *T, load LoadFunc[T], opts ...konfig.Option) bool {
+	t.Helper()
+
+	// Fields with invalid values keep their values in both loaders, so the values are compared even if there is an error
+	reflective := newConfig()
+	reflectiveErr := konfig.Pick(reflective, opts...)
+
+	generated := newConfig()
+	generatedErr := load(generated, opts...)
+
+	if fmt.Sprint(reflectiveErr) != fmt.Sprint(generatedErr) {
+		t.Errorf("generated and reflective loaders disagree on errors:\n\treflective: %v\n\tgenerated:  %v", reflectiveErr, generatedErr)
+		return false
+	}
+
+	if !reflect.DeepEqual(reflective, generated) {
+		t.Errorf("generated and reflective loaders disagree:\n\treflective: %+v\n\tgenerated:  %+v", reflective, generated)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,91 @@
+package konfigtest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/moorara/konfig"
+	"github.com/stretchr/testify/assert"
+)
+
+type config struct {
+	AgreeRegion string
+	AgreePort   uint16
+}
+
+// mockT records the errors reported by a test helper.
+type mockT struct {
+	testing.TB
+	errors []string
+}
+
+func (m *mockT) Helper() {}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAgree(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-agree.port=8080"}
+
+	err := os.Setenv("AGREE_REGION", "us-east-1")
+	assert.NoError(t, err)
+	defer os.Unsetenv("AGREE_REGION")
+
+	newConfig := func() *config {
+		return &config{AgreeRegion: "local"}
+	}
+
+	tests := []struct {
+		name           string
+		load           LoadFunc[config]
+		expectedResult bool
+		expectedErrors int
+	}{
+		{
+			"Agree",
+			func(c *config, opts ...konfig.Option) error {
+				c.AgreeRegion = "us-east-1"
+				c.AgreePort = 8080
+				return nil
+			},
+			true,
+			0,
+		},
+		{
+			"Disagree",
+			func(c *config, opts ...konfig.Option) error {
+				c.AgreeRegion = "us-east-1"
+				return nil
+			},
+			false,
+			1,
+		},
+		{
+			"DisagreeOnErrors",
+			func(c *config, opts ...konfig.Option) error {
+				c.AgreeRegion = "us-east-1"
+				c.AgreePort = 8080
+				return errors.New("invalid values")
+			},
+			false,
+			1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &mockT{TB: t}
+			result := Agree(m, newConfig, tc.load)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Len(t, m.errors, tc.expectedErrors)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package konfig
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Live holds the latest values of a configuration struct and allows reading them without any lock.
+// Every time a configuration file gets a new value, a fresh copy of the struct is updated and published atomically.
+// Values loaded from a Live should be treated as read-only.
+type Live[T any] struct {
+	r           *reader
+	mu          sync.Mutex // serializes publishing new snapshots
+	ptr         atomic.Pointer[T]
+	queues      []*queue[T]
+	events      []*queue[Event[T]]
+	stop        func()
+	stopSignals func()
+	closeOnce   sync.Once
+}
+
+// NewLive reads values for exported fields of a struct from either command-line flags, environment variables, or configuration files.
+// config is a struct (not a pointer) with default values for its fields.
+// It then watches any change to those fields that their values are read from configuration files.
+// Subscribers receive a consistent snapshot of the whole struct every time a field gets a new value.
+func NewLive[T any](config T, subscribers []chan T, opts ...Option) (*Live[T], error) {
+	return newLive(config, subscribers, nil, opts...)
+}
+
+// Event is a typed update for a configuration struct.
+type Event[T any] struct {
+	// Name is the name of the field that received a new value.
+	Name string
+	// Value is the new value of the field.
+	Value interface{}
+	// Config is a snapshot of the whole configuration struct after the update.
+	Config T
+}
+
+// WatchT is the generic version of Watch.
+// It first reads values for exported fields of a struct from either command-line flags, environment variables, or configuration files.
+// config is a struct (not a pointer) with default values for its fields.
+// It then watches any change to those fields that their values are read from configuration files and notifies subscribers with typed events.
+// The returned Live can be used for reading the latest values without any lock.
+func WatchT[T any](config T, subscribers []chan Event[T], opts ...Option) (*Live[T], error) {
+	return newLive(config, nil, subscribers, opts...)
+}
+
+func newLive[T any](config T, subscribers []chan T, events []chan Event[T], opts ...Option) (*Live[T], error) {
+	c := newReader(nil, opts...)
+
+	next := config
+	v, err := validateStruct(&next)
+	if err != nil {
+		c.log(1, "invalid configuration", "error", err)
+		return nil, err
+	}
+
+	if err := c.checkFields(v); err != nil {
+		c.log(1, "invalid configuration", "error", err)
+		return nil, err
+	}
+
+	c.registerFlags(v)
+
+	if err := c.checkNames(v); err != nil {
+		return nil, err
+	}
+
+	if err := c.readFields(v); err != nil {
+		return nil, err
+	}
+
+	l := &Live[T]{
+		r:      c,
+		queues: make([]*queue[T], len(subscribers)),
+		events: make([]*queue[Event[T]], len(events)),
+	}
+
+	l.ptr.Store(&next)
+
+	policy, size := c.deliveryPolicy()
+
+	// There is only one key for all snapshots, so the latest snapshot replaces a pending one when coalescing.
+	snapshotKey := func(T) string { return "" }
+	for i, sub := range subscribers {
+		l.queues[i] = newQueue(i, sub, snapshotKey, policy, size, c.metrics)
+	}
+
+	eventKey := func(e Event[T]) string { return e.Name }
+	for i, sub := range events {
+		l.events[i] = newQueue(i, sub, eventKey, policy, size, c.metrics)
+	}
+
+	l.stop, err = c.watchFiles(l.apply)
+	if err != nil {
+		l.closeQueues()
+		return nil, err
+	}
+
+	l.stopSignals = c.watchSignals(l.apply)
+
+	return l, nil
+}
+
+// apply sets a new value for a field on a copy of the current snapshot and publishes the copy.
+func (l *Live[T]) apply(f fieldInfo, val string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := *l.ptr.Load()
+	f.value = reflect.ValueOf(&next).Elem().FieldByName(f.name)
+
+	if changed, err := l.r.setFieldValue(f, val); err != nil || !changed {
+		return err
+	}
+
+	l.ptr.Store(&next)
+
+	l.r.log(4, "notifying subscribers of a new snapshot", "field", f.name, "subscribers", len(l.queues)+len(l.events))
+	for _, q := range l.queues {
+		q.push(next)
+	}
+
+	if len(l.events) > 0 {
+		e := Event[T]{
+			Name:   f.name,
+			Value:  f.value.Interface(),
+			Config: next,
+		}
+
+		for _, q := range l.events {
+			q.push(e)
+		}
+	}
+
+	return nil
+}
+
+func (l *Live[T]) closeQueues() {
+	for _, q := range l.queues {
+		q.close()
+	}
+
+	for _, q := range l.events {
+		q.close()
+	}
+}
+
+// Load returns the latest snapshot of the configuration struct.
+func (l *Live[T]) Load() T {
+	return *l.ptr.Load()
+}
+
+// Reload reads the configuration files again and publishes a new snapshot for every field that has a new value.
+func (l *Live[T]) Reload() error {
+	return l.r.reload(l.apply)
+}
+
+// Close stops watching configuration files and notifying subscribers.
+// It is safe to call Close more than once.
+func (l *Live[T]) Close() {
+	l.closeOnce.Do(func() {
+		l.stopSignals()
+		l.stop()
+		l.closeQueues()
+	})
+}
@@ -0,0 +1,255 @@
+package konfig
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLive(t *testing.T) {
+	t.Run("NonStruct", func(t *testing.T) {
+		l, err := NewLive(27, nil)
+		assert.Equal(t, errors.New("a non-struct type is passed"), err)
+		assert.Nil(t, l)
+	})
+
+	t.Run("Pointer", func(t *testing.T) {
+		l, err := NewLive(&struct{}{}, nil)
+		assert.Equal(t, errors.New("a non-struct type is passed"), err)
+		assert.Nil(t, l)
+	})
+
+	t.Run("InvalidTags", func(t *testing.T) {
+		type config struct {
+			LiveLabels map[string]string `format:"jsn"`
+		}
+
+		l, err := NewLive(config{}, nil, Strict())
+		assert.EqualError(t, err, "invalid tags: LiveLabels: unknown format: jsn")
+		assert.Nil(t, l)
+	})
+}
+
+func TestLive(t *testing.T) {
+	type liveConfig struct {
+		LiveLogLevel string
+		LiveReplicas int
+		LiveTags     []string
+	}
+
+	files := []struct {
+		varName   string
+		initValue string
+		newValue  string
+	}{
+		{"LIVE_LOG_LEVEL_FILE", "info", "debug"},
+		{"LIVE_REPLICAS_FILE", "2", "4"},
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	paths := []string{}
+	for _, f := range files {
+		tmpfile, err := ioutil.TempFile("", "gotest_")
+		assert.NoError(t, err)
+		defer os.Remove(tmpfile.Name())
+
+		_, err = tmpfile.WriteString(f.initValue)
+		assert.NoError(t, err)
+
+		err = tmpfile.Close()
+		assert.NoError(t, err)
+
+		err = os.Setenv(f.varName, tmpfile.Name())
+		assert.NoError(t, err)
+		defer os.Unsetenv(f.varName)
+
+		paths = append(paths, tmpfile.Name())
+	}
+
+	sub := make(chan liveConfig, 10)
+	l, err := NewLive(liveConfig{LiveTags: []string{"default"}}, []chan liveConfig{sub})
+	assert.NoError(t, err)
+	defer l.Close()
+
+	initial := l.Load()
+	assert.Equal(t, liveConfig{LiveLogLevel: "info", LiveReplicas: 2, LiveTags: []string{"default"}}, initial)
+
+	err = ioutil.WriteFile(paths[0], []byte(files[0].newValue), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case snapshot := <-sub:
+		assert.Equal(t, liveConfig{LiveLogLevel: "debug", LiveReplicas: 2, LiveTags: []string{"default"}}, snapshot)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for a snapshot")
+	}
+
+	err = ioutil.WriteFile(paths[1], []byte(files[1].newValue), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case snapshot := <-sub:
+		assert.Equal(t, liveConfig{LiveLogLevel: "debug", LiveReplicas: 4, LiveTags: []string{"default"}}, snapshot)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for a snapshot")
+	}
+
+	assert.Equal(t, liveConfig{LiveLogLevel: "debug", LiveReplicas: 4, LiveTags: []string{"default"}}, l.Load())
+
+	// Previously loaded snapshots are never mutated
+	assert.Equal(t, liveConfig{LiveLogLevel: "info", LiveReplicas: 2, LiveTags: []string{"default"}}, initial)
+}
+
+func TestLiveReload(t *testing.T) {
+	type reloadConfig struct {
+		ReloadLogLevel string
+	}
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("info")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	err = os.Setenv("RELOAD_LOG_LEVEL_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("RELOAD_LOG_LEVEL_FILE")
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	l, err := NewLive(reloadConfig{}, nil)
+	assert.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, reloadConfig{ReloadLogLevel: "info"}, l.Load())
+
+	err = ioutil.WriteFile(tmpfile.Name(), []byte("debug"), 0644)
+	assert.NoError(t, err)
+
+	err = l.Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, reloadConfig{ReloadLogLevel: "debug"}, l.Load())
+}
+
+func TestWatchT(t *testing.T) {
+	type eventConfig struct {
+		EventLogLevel string
+		EventRegion   string
+	}
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("info")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	err = os.Setenv("EVENT_LOG_LEVEL_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("EVENT_LOG_LEVEL_FILE")
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	ch := make(chan Event[eventConfig], 1)
+	l, err := WatchT(eventConfig{EventRegion: "local"}, []chan Event[eventConfig]{ch})
+	assert.NoError(t, err)
+	defer l.Close()
+
+	assert.Equal(t, eventConfig{EventLogLevel: "info", EventRegion: "local"}, l.Load())
+
+	err = ioutil.WriteFile(tmpfile.Name(), []byte("debug"), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case e := <-ch:
+		assert.Equal(t, Event[eventConfig]{
+			Name:   "EventLogLevel",
+			Value:  "debug",
+			Config: eventConfig{EventLogLevel: "debug", EventRegion: "local"},
+		}, e)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for an event")
+	}
+}
+
+func TestWatchTStrict(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	t.Run("InvalidTags", func(t *testing.T) {
+		type config struct {
+			EventLabels map[string]string `format:"jsn"`
+		}
+
+		l, err := WatchT(config{}, nil, Strict())
+		assert.EqualError(t, err, "invalid tags: EventLabels: unknown format: jsn")
+		assert.Nil(t, l)
+	})
+
+	t.Run("UnknownNames", func(t *testing.T) {
+		type config struct {
+			LogLevel string
+		}
+
+		err := os.Setenv("STRICT_T_LOGLEVEL", "debug")
+		assert.NoError(t, err)
+		defer os.Unsetenv("STRICT_T_LOGLEVEL")
+
+		l, err := WatchT(config{}, nil, Strict(), SkipFlag(), PrefixEnv("STRICT_T_"))
+		assert.EqualError(t, err, "unknown names: environment variable STRICT_T_LOGLEVEL (did you mean STRICT_T_LOG_LEVEL?)")
+		assert.Nil(t, l)
+	})
+}
+
+func TestLiveClose(t *testing.T) {
+	type closeConfig struct {
+		CloseLogLevel string
+	}
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	err = os.Setenv("CLOSE_LOG_LEVEL_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("CLOSE_LOG_LEVEL_FILE")
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	l, err := NewLive(closeConfig{}, nil, Polling(10*time.Millisecond), ReloadOnSignal())
+	assert.NoError(t, err)
+
+	l.Close()
+	l.Close()
+}
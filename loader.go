@@ -0,0 +1,214 @@
+package konfig
+
+import (
+	"errors"
+	"sync"
+)
+
+// Source is where the value of a configuration field is read from.
+type Source string
+
+const (
+	// SourceDefault means no value is read for a field and it has its default value.
+	SourceDefault Source = "default"
+	// SourceFlag means the value of a field is read from a command-line flag.
+	SourceFlag Source = "flag"
+	// SourceEnv means the value of a field is read from an environment variable.
+	SourceEnv Source = "env"
+	// SourceFile means the value of a field is read from a configuration file.
+	SourceFile Source = "file"
+)
+
+// Field describes a configuration field and where its value is read from.
+type Field struct {
+	// Name is the name of the struct field.
+	Name string
+	// Type is the Go type of the struct field.
+	Type string
+	// Flag is the name of the command-line flag for the field (empty if skipped).
+	Flag string
+	// Env is the name of the environment variable for the field (empty if skipped).
+	Env string
+	// FileEnv is the name of the environment variable for the file path of the field (empty if skipped).
+	FileEnv string
+	// Source is where the current value of the field is read from.
+	Source Source
+	// Path is the path to the configuration file specified for the field, if any.
+	Path string
+	// Deprecated is the deprecated name that the value of the field is read from, if any.
+	Deprecated string
+}
+
+// describeName returns the name of a source for describing a field.
+func describeName(name string) string {
+	if name == skip {
+		return ""
+	}
+	return name
+}
+
+// Loader reads configuration values using a set of options.
+// A Loader can be created once and used for reading configuration values many times.
+type Loader struct {
+	opts []Option
+
+	mu    sync.Mutex
+	r     *reader
+	apply applyFunc
+	stop  func()
+}
+
+// NewLoader creates a new loader with the given options.
+// Options can also be set through environment variables (i.e. KONFIG_DEBUG).
+func NewLoader(opts ...Option) *Loader {
+	return &Loader{
+		opts: opts,
+	}
+}
+
+// Pick reads values for exported fields of a struct from either command-line flags, environment variables, or configuration files.
+// Default values can also be specified.
+// You should pass the pointer to a struct for config; otherwise you will get an error.
+// While the loader is watching a struct, Reload and Describe keep referring to the watched struct.
+func (l *Loader) Pick(config interface{}) error {
+	r := newReader(nil, l.opts...)
+
+	v, err := validateStruct(config)
+	if err != nil {
+		r.log(1, "invalid configuration", "error", err)
+		return err
+	}
+
+	if err := r.checkFields(v); err != nil {
+		r.log(1, "invalid configuration", "error", err)
+		return err
+	}
+
+	r.registerFlags(v)
+
+	if err := r.checkNames(v); err != nil {
+		return err
+	}
+
+	err = r.readFields(v)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The watched struct is not replaced
+	if l.stop != nil {
+		return err
+	}
+
+	l.r = r
+	l.apply = func(f fieldInfo, val string) error {
+		_, err := r.setFieldValue(f, val)
+		if err == nil {
+			r.setSource(f.name, SourceFile)
+		}
+		return err
+	}
+
+	return err
+}
+
+// Watch first reads values for exported fields of a struct from either command-line flags, environment variables, or configuration files.
+// It then watches any change to those fields that their values are read from configuration files and notifies subscribers on a channel.
+// A loader can watch only one struct at a time; you should call Close to stop watching.
+func (l *Loader) Watch(config sync.Locker, subscribers []chan Update) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stop != nil {
+		return errors.New("the loader is already watching")
+	}
+
+	r := newReader(subscribers, l.opts...)
+
+	v, err := validateStruct(config)
+	if err != nil {
+		r.log(1, "invalid configuration", "error", err)
+		return err
+	}
+
+	if err := r.checkFields(v); err != nil {
+		r.log(1, "invalid configuration", "error", err)
+		return err
+	}
+
+	r.registerFlags(v)
+
+	if err := r.checkNames(v); err != nil {
+		return err
+	}
+
+	r.startQueues()
+
+	if err := r.readFields(v); err != nil {
+		r.closeQueues()
+		return err
+	}
+
+	apply := r.lockedApply(config)
+
+	stop, err := r.watchFiles(apply)
+	if err != nil {
+		r.closeQueues()
+		return err
+	}
+
+	stopSignals := r.watchSignals(apply)
+
+	l.r = r
+	l.apply = apply
+	l.stop = func() {
+		stopSignals()
+		stop()
+		// Subscriber channels are owned by the caller and they are not closed here.
+		// Once the queues are closed, no more updates will be sent to subscribers.
+		r.closeQueues()
+	}
+
+	return nil
+}
+
+// Reload reads the configuration files for the watched struct or the last struct passed to Pick again.
+// Subscribers will be notified of the fields that received new values.
+func (l *Loader) Reload() error {
+	l.mu.Lock()
+	r, apply := l.r, l.apply
+	l.mu.Unlock()
+
+	if r == nil {
+		return errors.New("no configuration is loaded")
+	}
+
+	return r.reload(apply)
+}
+
+// Describe returns the descriptions of fields for the watched struct or the last struct passed to Pick.
+// It reports the names of flags and environment variables for each field and where its value is read from.
+func (l *Loader) Describe() []Field {
+	l.mu.Lock()
+	r := l.r
+	l.mu.Unlock()
+
+	if r == nil {
+		return nil
+	}
+
+	return r.describe()
+}
+
+// Close stops watching configuration files and notifying subscribers.
+// It is safe to call Close more than once.
+func (l *Loader) Close() {
+	l.mu.Lock()
+	stop := l.stop
+	l.stop = nil
+	l.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+}
@@ -0,0 +1,233 @@
+package konfig
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoader(t *testing.T) {
+	l := NewLoader(PrefixEnv("APP_"), SkipFlag())
+
+	assert.NotNil(t, l)
+	assert.Len(t, l.opts, 2)
+}
+
+func TestLoaderPick(t *testing.T) {
+	type loaderConfig struct {
+		Region   string
+		Replicas int `flag:"-"`
+		Token    string
+		Timeout  time.Duration `env:"-" fileenv:"-"`
+	}
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("secret")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-loader.region=us-east-1"}
+
+	err = os.Setenv("LOADER_REPLICAS", "3")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOADER_REPLICAS")
+
+	err = os.Setenv("LOADER_TOKEN_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOADER_TOKEN_FILE")
+
+	l := NewLoader(PrefixFlag("loader."), PrefixEnv("LOADER_"), PrefixFileEnv("LOADER_"))
+	assert.Nil(t, l.Describe())
+
+	// The same loader can be used many times
+	for i := 0; i < 2; i++ {
+		cfg := &loaderConfig{Timeout: time.Minute}
+		err = l.Pick(cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, &loaderConfig{Region: "us-east-1", Replicas: 3, Token: "secret", Timeout: time.Minute}, cfg)
+	}
+
+	assert.Equal(t, []Field{
+		{Name: "Region", Type: "string", Flag: "loader.region", Env: "LOADER_REGION", FileEnv: "LOADER_REGION_FILE", Source: SourceFlag},
+		{Name: "Replicas", Type: "int", Flag: "", Env: "LOADER_REPLICAS", FileEnv: "LOADER_REPLICAS_FILE", Source: SourceEnv},
+		{Name: "Token", Type: "string", Flag: "loader.token", Env: "LOADER_TOKEN", FileEnv: "LOADER_TOKEN_FILE", Source: SourceFile, Path: tmpfile.Name()},
+		{Name: "Timeout", Type: "time.Duration", Flag: "loader.timeout", Env: "", FileEnv: "", Source: SourceDefault},
+	}, l.Describe())
+
+	err = l.Pick(loaderConfig{})
+	assert.Equal(t, errors.New("a non-pointer type is passed"), err)
+}
+
+func TestLoaderWatch(t *testing.T) {
+	type loaderConfig struct {
+		sync.Mutex
+		LoaderLevel string
+	}
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("info")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	err = os.Setenv("LOADER_LEVEL_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOADER_LEVEL_FILE")
+
+	l := NewLoader()
+
+	err = l.Reload()
+	assert.Equal(t, errors.New("no configuration is loaded"), err)
+
+	err = l.Watch(&loaderConfig{}, nil)
+	assert.NoError(t, err)
+
+	err = l.Watch(&loaderConfig{}, nil)
+	assert.Equal(t, errors.New("the loader is already watching"), err)
+
+	l.Close()
+	l.Close()
+
+	// The loader can watch again after it is closed
+	cfg := &loaderConfig{}
+	ch := make(chan Update, 1)
+	err = l.Watch(cfg, []chan Update{ch})
+	assert.NoError(t, err)
+	defer l.Close()
+
+	cfg.Lock()
+	assert.Equal(t, "info", cfg.LoaderLevel)
+	cfg.Unlock()
+
+	assert.Equal(t, Update{"LoaderLevel", "info"}, <-ch)
+
+	// Whether the watcher or reloading sets the new value first, subscribers are notified only once
+	err = ioutil.WriteFile(tmpfile.Name(), []byte("debug"), 0644)
+	assert.NoError(t, err)
+
+	err = l.Reload()
+	assert.NoError(t, err)
+
+	cfg.Lock()
+	assert.Equal(t, "debug", cfg.LoaderLevel)
+	cfg.Unlock()
+
+	assert.Equal(t, Update{"LoaderLevel", "debug"}, <-ch)
+
+	assert.Equal(t, []Field{
+		{Name: "LoaderLevel", Type: "string", Flag: "loader.level", Env: "LOADER_LEVEL", FileEnv: "LOADER_LEVEL_FILE", Source: SourceFile, Path: tmpfile.Name()},
+	}, l.Describe())
+
+	// Picking another struct does not replace the watched struct
+	err = l.Pick(&struct{ LoaderName string }{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "LoaderLevel", l.Describe()[0].Name)
+
+	err = ioutil.WriteFile(tmpfile.Name(), []byte("warn"), 0644)
+	assert.NoError(t, err)
+
+	err = l.Reload()
+	assert.NoError(t, err)
+
+	cfg.Lock()
+	assert.Equal(t, "warn", cfg.LoaderLevel)
+	cfg.Unlock()
+
+	assert.Equal(t, Update{"LoaderLevel", "warn"}, <-ch)
+}
+
+func TestLoaderWatchBlock(t *testing.T) {
+	type blockConfig struct {
+		sync.Mutex
+		BlockLevel string
+	}
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("info")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	err = os.Setenv("BLOCK_LEVEL_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("BLOCK_LEVEL_FILE")
+
+	// Files are only read again by reloading
+	l := NewLoader(Backpressure(BackpressureBlock, 1), Polling(time.Hour))
+	cfg := &blockConfig{}
+	ch := make(chan Update)
+	err = l.Watch(cfg, []chan Update{ch})
+	assert.NoError(t, err)
+	defer l.Close()
+
+	// The initial update is being sent and the second one fills the queue
+	time.Sleep(50 * time.Millisecond)
+	err = ioutil.WriteFile(tmpfile.Name(), []byte("debug"), 0644)
+	assert.NoError(t, err)
+	err = l.Reload()
+	assert.NoError(t, err)
+
+	// The third update waits for room in the queue
+	err = ioutil.WriteFile(tmpfile.Name(), []byte("warn"), 0644)
+	assert.NoError(t, err)
+	reloaded := make(chan error, 1)
+	go func() {
+		reloaded <- l.Reload()
+	}()
+
+	// The struct is not locked while waiting
+	locked := make(chan string, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cfg.Lock()
+		defer cfg.Unlock()
+		locked <- cfg.BlockLevel
+	}()
+
+	select {
+	case level := <-locked:
+		assert.Equal(t, "warn", level)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for locking the struct")
+	}
+
+	assert.Equal(t, Update{"BlockLevel", "info"}, <-ch)
+	assert.Equal(t, Update{"BlockLevel", "debug"}, <-ch)
+	assert.Equal(t, Update{"BlockLevel", "warn"}, <-ch)
+	assert.NoError(t, <-reloaded)
+}
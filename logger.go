@@ -0,0 +1,77 @@
+package konfig
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// Logger is the interface for logging what happens when reading and watching configuration values.
+// verbosity is the same as the levels for Debug option (1 for errors and higher for more details).
+// keyvals are pairs of keys and values such as "field", "source", and "path".
+type Logger interface {
+	Log(verbosity uint, msg string, keyvals ...interface{})
+}
+
+// LoggerFunc is an adapter to allow the use of ordinary functions as loggers.
+type LoggerFunc func(verbosity uint, msg string, keyvals ...interface{})
+
+// Log calls f(verbosity, msg, keyvals...).
+func (f LoggerFunc) Log(verbosity uint, msg string, keyvals ...interface{}) {
+	f(verbosity, msg, keyvals...)
+}
+
+// stdLogger writes logs using the standard log package.
+type stdLogger struct{}
+
+// Log writes a log line such as "[Field] message key=value".
+func (stdLogger) Log(verbosity uint, msg string, keyvals ...interface{}) {
+	var b strings.Builder
+
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == "field" {
+			fmt.Fprintf(&b, "[%v] ", keyvals[i+1])
+		}
+	}
+
+	b.WriteString(msg)
+
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] != "field" {
+			fmt.Fprintf(&b, " %v=%v", keyvals[i], keyvals[i+1])
+		}
+	}
+
+	log.Println(b.String())
+}
+
+// slogLogger writes structured logs using a slog.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger creates a new logger that writes structured logs using a slog.Logger.
+// Errors are logged at error level, initialization and updates at info level, and the rest at debug level.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return &slogLogger{
+		logger: logger,
+	}
+}
+
+// slogLevel maps a verbosity level to a slog level.
+func slogLevel(verbosity uint) slog.Level {
+	switch {
+	case verbosity <= 1:
+		return slog.LevelError
+	case verbosity <= 3:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+func (l *slogLogger) Log(verbosity uint, msg string, keyvals ...interface{}) {
+	l.logger.Log(context.Background(), slogLevel(verbosity), msg, keyvals...)
+}
@@ -0,0 +1,276 @@
+package konfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerFunc(t *testing.T) {
+	var verbosity uint
+	var msg string
+	var keyvals []interface{}
+
+	logger := LoggerFunc(func(v uint, m string, kv ...interface{}) {
+		verbosity, msg, keyvals = v, m, kv
+	})
+
+	logger.Log(3, "received an update", "field", "LogLevel")
+
+	assert.Equal(t, uint(3), verbosity)
+	assert.Equal(t, "received an update", msg)
+	assert.Equal(t, []interface{}{"field", "LogLevel"}, keyvals)
+}
+
+func TestStdLogger(t *testing.T) {
+	tests := []struct {
+		name           string
+		msg            string
+		keyvals        []interface{}
+		expectedOutput string
+	}{
+		{
+			"NoKeyValue",
+			"reading configuration values",
+			nil,
+			"reading configuration values\n",
+		},
+		{
+			"WithField",
+			"value read from file",
+			[]interface{}{"field", "LogLevel", "source", SourceFile, "path", "/etc/log-level"},
+			"[LogLevel] value read from file source=file path=/etc/log-level\n",
+		},
+		{
+			"WithoutField",
+			"polling files",
+			[]interface{}{"files", 2, "interval", "1s"},
+			"polling files files=2 interval=1s\n",
+		},
+	}
+
+	origFlags, origWriter := log.Flags(), log.Writer()
+	defer func() {
+		log.SetFlags(origFlags)
+		log.SetOutput(origWriter)
+	}()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			log.SetFlags(0)
+			log.SetOutput(buf)
+
+			stdLogger{}.Log(1, tc.msg, tc.keyvals...)
+
+			assert.Equal(t, tc.expectedOutput, buf.String())
+		})
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	tests := []struct {
+		name           string
+		verbosity      uint
+		msg            string
+		keyvals        []interface{}
+		expectedRecord map[string]interface{}
+	}{
+		{
+			"Error",
+			1,
+			"cannot set value",
+			[]interface{}{"field", "Port", "error", "invalid syntax"},
+			map[string]interface{}{"level": "ERROR", "msg": "cannot set value", "field": "Port", "error": "invalid syntax"},
+		},
+		{
+			"Info",
+			3,
+			"received an update",
+			[]interface{}{"field", "LogLevel", "source", SourceFile, "path", "/etc/log-level"},
+			map[string]interface{}{"level": "INFO", "msg": "received an update", "field": "LogLevel", "source": "file", "path": "/etc/log-level"},
+		},
+		{
+			"Debug",
+			5,
+			"flag registered",
+			[]interface{}{"field", "LogLevel", "flag", "log.level"},
+			map[string]interface{}{"level": "DEBUG", "msg": "flag registered", "field": "LogLevel", "flag": "log.level"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+				Level: slog.LevelDebug,
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			})
+
+			logger := NewSlogLogger(slog.New(handler))
+			logger.Log(tc.verbosity, tc.msg, tc.keyvals...)
+
+			record := map[string]interface{}{}
+			err := json.Unmarshal(buf.Bytes(), &record)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRecord, record)
+		})
+	}
+}
+
+func TestPickWithLogger(t *testing.T) {
+	type entry struct {
+		msg     string
+		keyvals []interface{}
+	}
+
+	var entries []entry
+	logger := LoggerFunc(func(verbosity uint, msg string, keyvals ...interface{}) {
+		entries = append(entries, entry{msg, keyvals})
+	})
+
+	err := os.Setenv("LOGGER_REGION", "us-east-1")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOGGER_REGION")
+
+	config := struct {
+		LoggerRegion string
+	}{}
+
+	err = Pick(&config, Logging(logger), SkipFlag(), SkipFileEnv())
+	assert.NoError(t, err)
+
+	assert.Contains(t, entries, entry{
+		"value read from environment variable",
+		[]interface{}{"field", "LoggerRegion", "source", SourceEnv, "env", "LOGGER_REGION", "value", "us-east-1"},
+	})
+}
+
+func TestPickWithLoggerFile(t *testing.T) {
+	type entry struct {
+		msg     string
+		keyvals []interface{}
+	}
+
+	var entries []entry
+	logger := LoggerFunc(func(verbosity uint, msg string, keyvals ...interface{}) {
+		entries = append(entries, entry{msg, keyvals})
+	})
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("s3cr3t")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	err = os.Setenv("LOGGER_PASSWORD_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOGGER_PASSWORD_FILE")
+
+	config := struct {
+		LoggerPassword string
+	}{}
+
+	err = Pick(&config, Logging(logger), SkipFlag(), SkipEnv())
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", config.LoggerPassword)
+
+	// The contents of files are not logged
+	assert.Contains(t, entries, entry{
+		"value read from file",
+		[]interface{}{"field", "LoggerPassword", "source", SourceFile, "path", tmpfile.Name(), "length", 6},
+	})
+	assert.Contains(t, entries, entry{
+		"setting string value",
+		[]interface{}{"field", "LoggerPassword", "length", 6},
+	})
+
+	for _, e := range entries {
+		assert.NotContains(t, fmt.Sprint(e.keyvals...), "s3cr3t", e.msg)
+	}
+}
+
+func TestWatchWithLoggerFile(t *testing.T) {
+	type config struct {
+		sync.Mutex
+		LoggerToken     string
+		LoggerUpstreams []upstream
+	}
+
+	var mu sync.Mutex
+	var logs []string
+	logger := LoggerFunc(func(verbosity uint, msg string, keyvals ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, msg+" "+fmt.Sprint(keyvals...))
+	})
+
+	dir, err := ioutil.TempDir("", "gotest_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tokenPath := filepath.Join(dir, "token")
+	err = ioutil.WriteFile(tokenPath, []byte("t0k3n"), 0644)
+	assert.NoError(t, err)
+
+	hostPath := filepath.Join(dir, "host")
+	err = ioutil.WriteFile(hostPath, []byte("h0st.example.com"), 0644)
+	assert.NoError(t, err)
+
+	err = os.Setenv("LOGGER_TOKEN_FILE", tokenPath)
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOGGER_TOKEN_FILE")
+
+	err = os.Setenv("LOGGER_UPSTREAMS_0_HOST_FILE", hostPath)
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOGGER_UPSTREAMS_0_HOST_FILE")
+
+	c := &config{}
+	ch := make(chan Update, 10)
+	close, err := Watch(c, []chan Update{ch}, Logging(logger), SkipFlag(), Polling(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer close()
+
+	<-ch
+	<-ch
+
+	err = ioutil.WriteFile(tokenPath, []byte("n3wt0k3n"), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case update := <-ch:
+		assert.Equal(t, Update{"LoggerToken", "n3wt0k3n"}, update)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for a new value")
+	}
+
+	close()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.NotEmpty(t, logs)
+	for _, l := range logs {
+		assert.NotContains(t, l, "t0k3n")
+		assert.NotContains(t, l, "h0st")
+	}
+}
@@ -0,0 +1,263 @@
+package konfig
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// Lookup reads configuration values for struct fields for the code generated by konfig-gen.
+// The generated code parses and sets the values of fields without reflection.
+// Lookup itself only uses reflection for registering flags with default values, checking fields, expanding values, and setting lists of structs.
+// It follows the same rules as Pick for naming flags and environment variables and for the precedence of sources.
+// The fields and names are only checked the same as Pick (i.e. in strict mode) if Check is called.
+type Lookup struct {
+	r       *reader
+	fields  []lookupField
+	read    bool
+	invalid []string
+	err     error // the first error for expanding values
+}
+
+// LookupKey identifies a field registered with a lookup.
+type LookupKey int
+
+// lookupField is a field registered with a lookup.
+// The names of the flag and environment variables for the field are worked out when the field is registered
+// and are cached for the next lookups with the same options.
+type lookupField struct {
+	plan         fieldPlan
+	defaultValue interface{}
+	from         string // where the value is read from (only used for reporting errors)
+}
+
+// NewLookup creates a new lookup with the given options.
+// Options can also be set through environment variables (i.e. KONFIG_DEBUG).
+func NewLookup(opts ...Option) *Lookup {
+	r := newReader(nil, opts...)
+
+	// Command-line arguments are parsed only once for all fields
+	r.args = parseFlagArgs(os.Args)
+
+	return &Lookup{
+		r: r,
+	}
+}
+
+// RegisterFlag registers a struct field and defines a flag for it, so flag.Parse() can be called.
+// tag is the struct tag of the field and dataType is the Go type of the field.
+// The returned key is used for reading the value of the field.
+// RegisterFlag should be called for all fields before calling Value, so fields can refer to each other.
+func (l *Lookup) RegisterFlag(fieldName, tag, dataType string, defaultValue interface{}) LookupKey {
+	p := l.r.fieldPlan(fieldName, reflect.StructTag(tag))
+	p.dataType = dataType
+	_, p.isBool = defaultValue.(bool)
+	p.binary = (dataType == "[]uint8" || dataType == "[]byte") && reflect.StructTag(tag).Get(tagSep) == ""
+
+	t := reflect.TypeOf(defaultValue)
+	p.structList = p.format == "" && t != nil && isStructList(t)
+
+	l.fields = append(l.fields, lookupField{
+		plan:         p,
+		defaultValue: defaultValue,
+	})
+
+	if p.flagName != skip {
+		l.r.defineFlag(&p, defaultValue)
+		if p.structList {
+			l.r.defineElemFlags(&p, t.Elem())
+		}
+	}
+
+	return LookupKey(len(l.fields) - 1)
+}
+
+// Check reports the fields with invalid tags and the fields that cannot be read the same as Pick.
+// In strict mode, the environment variables and flags that do not belong to any field are reported too.
+// config is the pointer to the struct and Check should be called after registering all fields, so their flags are known.
+func (l *Lookup) Check(config interface{}) error {
+	v, err := validateStruct(config)
+	if err != nil {
+		l.r.log(1, "invalid configuration", "error", err)
+		return err
+	}
+
+	if err := l.r.checkFields(v); err != nil {
+		l.r.log(1, "invalid configuration", "error", err)
+		return err
+	}
+
+	return l.r.checkNames(v)
+}
+
+// Value reads the string value for a struct field from either command-line flags, environment variables, or configuration files.
+// The second returned value is the list separator for the field.
+// If the value cannot be expanded, decoded, or converted, the error is reported and an empty string is returned.
+func (l *Lookup) Value(k LookupKey) (string, string) {
+	f := &l.fields[k]
+	p := &f.plan
+
+	val, source, path, name := l.r.getFieldValue(p)
+	f.from = describeSource(source, name, path)
+
+	if l.r.expand {
+		l.readValues()
+
+		var err error
+		if val, err = l.r.expandValue(p.name, val); err != nil {
+			l.r.log(1, "cannot expand value", "field", p.name, "error", err)
+			if l.err == nil {
+				l.err = err
+			}
+			return "", p.listSep
+		}
+	}
+
+	// `encoding:"..."`
+	if p.encoding != "" && val != "" {
+		var err error
+		if val, err = decodeValue(p.encoding, val); err != nil {
+			l.r.log(1, "cannot decode value", "field", p.name, "encoding", p.encoding, "error", err)
+			l.Invalid(k, err)
+			return "", p.listSep
+		}
+	}
+
+	// `unit:"..."`
+	if p.unit != "" && val != "" {
+		var err error
+		if val, err = convertUnit(p.unit, val, p.listSep); err != nil {
+			l.r.log(1, "cannot convert value", "field", p.name, "unit", p.unit, "error", err)
+			l.Invalid(k, err)
+			return "", p.listSep
+		}
+	}
+
+	return val, p.listSep
+}
+
+// Bytes reads the value for a binary struct field (i.e. []byte) from either command-line flags, environment variables, or configuration files.
+// The contents of files are used as they are and the values of flags and environment variables are base64-decoded.
+// If no value is read or the value cannot be decoded, nil is returned.
+func (l *Lookup) Bytes(k LookupKey) []byte {
+	f := &l.fields[k]
+	p := &f.plan
+
+	val, source, path, name := l.r.getFieldValue(p)
+	f.from = describeSource(source, name, path)
+	if val == "" {
+		return nil
+	}
+
+	encoding := p.encoding
+	if encoding == "" && source != SourceFile {
+		encoding = "base64"
+	}
+
+	if encoding != "" {
+		var err error
+		if val, err = decodeValue(encoding, val); err != nil {
+			l.r.log(1, "cannot decode value", "field", p.name, "encoding", encoding, "error", err)
+			l.Invalid(k, err)
+			return nil
+		}
+	}
+
+	return []byte(val)
+}
+
+// Structs reads the value for a list of structs (i.e. []Upstream) from either a JSON value
+// or indexed names for the fields of its elements (i.e. UPSTREAMS_0_HOST).
+// list is a pointer to the field.
+// Unlike other values, the fields of elements are set using reflection.
+// If no value is read or the value cannot be set, the field keeps its current value.
+func (l *Lookup) Structs(k LookupKey, list interface{}) {
+	v := reflect.ValueOf(list).Elem()
+
+	lf := &l.fields[k]
+	p := &lf.plan
+
+	f := fieldInfo{
+		value:    v,
+		name:     p.name,
+		listSep:  p.listSep,
+		encoding: p.encoding,
+		list:     p,
+	}
+
+	if l.r.expand {
+		l.readValues()
+	}
+
+	val, source, path, name := l.r.getFieldValue(p)
+	lf.from = describeSource(source, name, path)
+
+	if val == "" {
+		elems := l.r.readElems(p, v.Type().Elem())
+		if !hasElemValues(elems) {
+			return
+		}
+		f.indexed = true
+		f.elems = elems
+	} else if l.r.expand {
+		var err error
+		if val, err = l.r.expandValue(p.name, val); err != nil {
+			l.r.log(1, "cannot expand value", "field", p.name, "error", err)
+			if l.err == nil {
+				l.err = err
+			}
+			return
+		}
+	}
+
+	if _, err := l.r.setFieldValue(f, val); err != nil {
+		l.r.log(1, "cannot set value", "field", p.name, "error", err)
+		// The errors for elements already name the fields and sources of elements
+		if f.indexed {
+			l.invalid = append(l.invalid, err.Error())
+		} else {
+			l.invalid = append(l.invalid, fmt.Sprintf("%s from %s: %s", p.name, lf.from, err))
+		}
+	}
+}
+
+// Invalid reports an error for a value that cannot be parsed or set, so it is returned by Err.
+// It should be called after reading the value of the field.
+func (l *Lookup) Invalid(k LookupKey, err error) {
+	f := &l.fields[k]
+
+	// `format:"..."`
+	if f.plan.format != "" {
+		err = fmt.Errorf("invalid %s value: %s", f.plan.format, err)
+	}
+
+	l.r.log(1, "cannot set value", "field", f.plan.name, "error", err)
+	l.invalid = append(l.invalid, fmt.Sprintf("%s from %s: %s", f.plan.name, f.from, err))
+}
+
+// Err returns the same error as Pick for the values read by a lookup.
+// The error for the first value that cannot be expanded is joined with an error listing all values that cannot be set.
+func (l *Lookup) Err() error {
+	return readError(l.err, l.invalid)
+}
+
+// readValues reads the values of all registered fields once, so fields can refer to each other when expanding values.
+func (l *Lookup) readValues() {
+	if l.read {
+		return
+	}
+
+	for i := range l.fields {
+		f := &l.fields[i]
+
+		// Binary values are never expanded
+		if f.plan.binary {
+			continue
+		}
+
+		val, _, _, _ := l.r.getFieldValue(&f.plan)
+		l.r.setFieldRawValue(f.plan.name, val, reflect.ValueOf(f.defaultValue), f.plan.listSep)
+	}
+
+	l.read = true
+}
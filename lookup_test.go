@@ -0,0 +1,254 @@
+package konfig
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLookup(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-lookup.region=us-east-1"}
+
+	l := NewLookup(PrefixEnv("APP_"))
+
+	assert.NotNil(t, l)
+	assert.Equal(t, "APP_", l.r.prefixEnv)
+	assert.Equal(t, flagArgs{"lookup.region": "us-east-1"}, l.r.args)
+}
+
+func TestLookupRegisterFlag(t *testing.T) {
+	l := NewLookup()
+
+	assert.Equal(t, LookupKey(0), l.RegisterFlag("LookupEnabled", "", "bool", true))
+	assert.Equal(t, LookupKey(1), l.RegisterFlag("LookupTimeout", `flag:"lookup.timeout.custom"`, "time.Duration", "30s"))
+	assert.Equal(t, LookupKey(2), l.RegisterFlag("LookupSkipped", `flag:"-"`, "string", ""))
+
+	// The names are worked out once when a field is registered
+	assert.Equal(t, "lookup.enabled", l.fields[0].plan.flagName)
+	assert.Equal(t, "LOOKUP_TIMEOUT", l.fields[1].plan.envName)
+	assert.True(t, l.fields[0].plan.isBool)
+
+	f := flag.Lookup("lookup.enabled")
+	assert.NotNil(t, f)
+	assert.Equal(t, "true", f.DefValue)
+
+	f = flag.Lookup("lookup.timeout.custom")
+	assert.NotNil(t, f)
+
+	assert.Nil(t, flag.Lookup("lookup.skipped"))
+	assert.Nil(t, flag.Lookup("-"))
+}
+
+func TestLookupValue(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-lookup.flag=from-flag"}
+
+	err := os.Setenv("LOOKUP_ENV", "from-env")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_ENV")
+
+	err = os.Setenv("LOOKUP_ENCODED", "66726f6d2d686578")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_ENCODED")
+
+	err = os.Setenv("LOOKUP_SIZES", "1KiB,2MB")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_SIZES")
+
+	err = os.Setenv("LOOKUP_EXPAND", "${.LookupEnv}/${.LookupFlag}")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_EXPAND")
+
+	err = os.Setenv("LOOKUP_CYCLE", "${.LookupCycle}")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_CYCLE")
+
+	tests := []struct {
+		name            string
+		opts            []Option
+		fieldName       string
+		tag             string
+		expectedValue   string
+		expectedListSep string
+		expectedError   string
+	}{
+		{"Flag", nil, "LookupFlag", "", "from-flag", ",", ""},
+		{"Env", nil, "LookupEnv", "", "from-env", ",", ""},
+		{"Tag", nil, "LookupCustom", `env:"LOOKUP_ENV" sep:"|"`, "from-env", "|", ""},
+		{"NoValue", nil, "LookupMissing", "", "", ",", ""},
+		{"WithOptions", []Option{SkipEnv(), ListSep(";")}, "LookupEnv", "", "", ";", ""},
+		{"Decode", nil, "LookupEncoded", `encoding:"hex"`, "from-hex", ",", ""},
+		{"DecodeError", nil, "LookupEnv", `encoding:"hex"`, "", ",", "invalid values: LookupEnv from environment variable LOOKUP_ENV: invalid hex value: encoding/hex: invalid byte: U+0072 'r'"},
+		{"Convert", nil, "LookupSizes", `unit:"bytes"`, "1024,2000000", ",", ""},
+		{"ConvertError", nil, "LookupEnv", `unit:"bytes"`, "", ",", "invalid values: LookupEnv from environment variable LOOKUP_ENV: invalid byte size: from-env"},
+		{"Expand", []Option{Expand()}, "LookupExpand", "", "from-env/from-flag", ",", ""},
+		{"ExpandError", []Option{Expand()}, "LookupCycle", "", "", ",", "cycle in references: LookupCycle -> LookupCycle"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLookup(tc.opts...)
+			l.RegisterFlag("LookupFlag", "", "string", "")
+			l.RegisterFlag("LookupEnv", "", "string", "")
+			l.RegisterFlag("LookupCycle", `env:"LOOKUP_CYCLE"`, "string", "")
+			k := l.RegisterFlag(tc.fieldName, tc.tag, "string", "")
+
+			value, listSep := l.Value(k)
+
+			assert.Equal(t, tc.expectedValue, value)
+			assert.Equal(t, tc.expectedListSep, listSep)
+			if tc.expectedError == "" {
+				assert.NoError(t, l.Err())
+			} else {
+				assert.EqualError(t, l.Err(), tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestLookupBytes(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-lookup.flag.bytes=c2VjcmV0"}
+
+	err := os.Setenv("LOOKUP_ENV_BYTES", "!invalid!")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_ENV_BYTES")
+
+	err = os.Setenv("LOOKUP_HEX_BYTES", "736563726574")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_HEX_BYTES")
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("secret\n")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	err = os.Setenv("LOOKUP_FILE_BYTES_FILE", tmpfile.Name())
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_FILE_BYTES_FILE")
+
+	tests := []struct {
+		name          string
+		fieldName     string
+		tag           string
+		expected      []byte
+		expectedError string
+	}{
+		{"Flag", "LookupFlagBytes", "", []byte("secret"), ""},
+		{"File", "LookupFileBytes", "", []byte("secret\n"), ""},
+		{"Encoding", "LookupHexBytes", `encoding:"hex"`, []byte("secret"), ""},
+		{"InvalidValue", "LookupEnvBytes", "", nil, "invalid values: LookupEnvBytes from environment variable LOOKUP_ENV_BYTES: invalid base64 value: illegal base64 data at input byte 0"},
+		{"NoValue", "LookupMissingBytes", "", nil, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLookup()
+			k := l.RegisterFlag(tc.fieldName, tc.tag, "[]uint8", []byte(nil))
+
+			assert.Equal(t, tc.expected, l.Bytes(k))
+			if tc.expectedError == "" {
+				assert.NoError(t, l.Err())
+			} else {
+				assert.EqualError(t, l.Err(), tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestLookupInvalid(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-lookup.port=invalid"}
+
+	err := os.Setenv("LOOKUP_ROUTES", "{")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_ROUTES")
+
+	l := NewLookup()
+	kPort := l.RegisterFlag("LookupPort", "", "int", 0)
+	kRoutes := l.RegisterFlag("LookupRoutes", `format:"json"`, "map[string]int", map[string]int(nil))
+
+	assert.NoError(t, l.Err())
+
+	l.Value(kPort)
+	l.Invalid(kPort, errors.New("invalid port"))
+	l.Value(kRoutes)
+	l.Invalid(kRoutes, errors.New("unexpected end of JSON input"))
+
+	assert.EqualError(t, l.Err(), "invalid values: LookupPort from flag lookup.port: invalid port; "+
+		"LookupRoutes from environment variable LOOKUP_ROUTES: invalid json value: unexpected end of JSON input")
+}
+
+func TestLookupCheck(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	err := os.Setenv("LOOKUP_CHECK_HOTS", "localhost")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_CHECK_HOTS")
+
+	type config struct {
+		Host string
+		Port int
+	}
+
+	type invalidConfig struct {
+		Token string `encoding:"b64"`
+	}
+
+	t.Run("NonPointer", func(t *testing.T) {
+		l := NewLookup()
+		assert.EqualError(t, l.Check(config{}), "a non-pointer type is passed")
+	})
+
+	t.Run("InvalidTags", func(t *testing.T) {
+		c := &invalidConfig{}
+		l := NewLookup()
+		l.RegisterFlag("Token", `encoding:"b64"`, "string", c.Token)
+
+		assert.EqualError(t, l.Check(c), "invalid tags: Token: unknown encoding: b64")
+	})
+
+	t.Run("UnknownNames", func(t *testing.T) {
+		c := &config{}
+		l := NewLookup(PrefixEnv("LOOKUP_CHECK_"), PrefixFlag("lookup.check."), Strict())
+		l.RegisterFlag("Host", "", "string", c.Host)
+		l.RegisterFlag("Port", "", "int", c.Port)
+
+		assert.EqualError(t, l.Check(c), "unknown names: environment variable LOOKUP_CHECK_HOTS (did you mean LOOKUP_CHECK_HOST?)")
+	})
+
+	t.Run("NotStrict", func(t *testing.T) {
+		c := &config{}
+		l := NewLookup(PrefixEnv("LOOKUP_CHECK_"), PrefixFlag("lookup.check.nonstrict."))
+		l.RegisterFlag("Host", "", "string", c.Host)
+		l.RegisterFlag("Port", "", "int", c.Port)
+
+		assert.NoError(t, l.Check(c))
+	})
+}
@@ -0,0 +1,256 @@
+package konfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Naming is the interface for making the names of command-line flags and environment variables for struct fields.
+// words are the words in the name of a field (i.e. DatabaseURL --> Database, URL).
+type Naming interface {
+	Name(words []string) string
+}
+
+// NamingFunc is an adapter to allow the use of ordinary functions as namings.
+// The names for the fields of the elements of lists of structs are made by calling the function
+// with the name of the list, the index of the element, and the name of the field (i.e. log.level, 0, host).
+type NamingFunc func(words []string) string
+
+// Name calls f(words).
+func (f NamingFunc) Name(words []string) string {
+	return f(words)
+}
+
+// Case is a predefined naming.
+type Case int
+
+const (
+	// DotCase joins lower-case words with dots (i.e. database.url).
+	// This is the default naming for command-line flags.
+	DotCase Case = iota
+	// KebabCase joins lower-case words with dashes (i.e. database-url).
+	KebabCase
+	// SnakeCase joins lower-case words with underscores (i.e. database_url).
+	SnakeCase
+	// ScreamingSnakeCase joins upper-case words with underscores (i.e. DATABASE_URL).
+	// This is the default naming for environment variables and file environment variables.
+	ScreamingSnakeCase
+	// CamelCase joins words with the first letter of every word except the first one in upper-case (i.e. databaseURL).
+	CamelCase
+)
+
+// String returns a human-readable name for a case.
+func (c Case) String() string {
+	switch c {
+	case DotCase:
+		return "Dot"
+	case KebabCase:
+		return "Kebab"
+	case SnakeCase:
+		return "Snake"
+	case ScreamingSnakeCase:
+		return "ScreamingSnake"
+	case CamelCase:
+		return "Camel"
+	}
+
+	return fmt.Sprintf("Case(%d)", int(c))
+}
+
+// parseCase parses a case from its name (i.e. dot, kebab, snake, screaming_snake, or camel).
+func parseCase(s string) (Case, bool) {
+	switch strings.ToLower(s) {
+	case "dot":
+		return DotCase, true
+	case "kebab":
+		return KebabCase, true
+	case "snake":
+		return SnakeCase, true
+	case "screaming_snake", "screamingsnake":
+		return ScreamingSnakeCase, true
+	case "camel":
+		return CamelCase, true
+	}
+
+	return DotCase, false
+}
+
+// Name joins the words in the name of a field based on the case.
+func (c Case) Name(words []string) string {
+	switch c {
+	case KebabCase:
+		return strings.ToLower(strings.Join(words, "-"))
+	case SnakeCase:
+		return strings.ToLower(strings.Join(words, "_"))
+	case ScreamingSnakeCase:
+		return strings.ToUpper(strings.Join(words, "_"))
+	case CamelCase:
+		names := make([]string, len(words))
+		for i, w := range words {
+			// Upper-case words (i.e. URL) are kept as they are except for the first word
+			if i == 0 || w != strings.ToUpper(w) {
+				w = strings.ToLower(w)
+			}
+			names[i] = w
+		}
+		return joinCamel(names)
+	default:
+		return strings.ToLower(strings.Join(words, "."))
+	}
+}
+
+// join joins names without changing their cases.
+// It is used for making the names of the fields of elements of lists of structs from the names of their lists,
+// so the names set using struct tags are kept as they are.
+func (c Case) join(names []string) string {
+	switch c {
+	case KebabCase:
+		return strings.Join(names, "-")
+	case SnakeCase, ScreamingSnakeCase:
+		return strings.Join(names, "_")
+	case CamelCase:
+		return joinCamel(names)
+	default:
+		return strings.Join(names, ".")
+	}
+}
+
+// joinCamel joins names by changing the first letter of every name except the first one to upper-case.
+func joinCamel(names []string) string {
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 && name != "" {
+			r, size := utf8.DecodeRuneInString(name)
+			name = string(unicode.ToUpper(r)) + name[size:]
+		}
+		b.WriteString(name)
+	}
+
+	return b.String()
+}
+
+// joinNames joins the names for the field of an element of a list of structs using a naming.
+func joinNames(n Naming, names ...string) string {
+	if c, ok := n.(Case); ok {
+		return c.join(names)
+	}
+
+	return n.Name(names)
+}
+
+// fileSuffix returns the suffix that a naming adds to the names of file environment variables (i.e. _FILE).
+func fileSuffix(n Naming) string {
+	return strings.TrimPrefix(n.Name([]string{"X", "File"}), n.Name([]string{"X"}))
+}
+
+// namingString returns a human-readable name for a naming.
+func namingString(n Naming) string {
+	if s, ok := n.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return "Custom"
+}
+
+// isNamingComparable determines whether or not a naming can be used as a key for caching plans.
+// Namings created using NamingFunc cannot be compared, since functions are not comparable.
+func isNamingComparable(n Naming) bool {
+	return n == nil || reflect.TypeOf(n).Comparable()
+}
+
+// flagNaming returns the naming for command-line flags.
+func (r *reader) flagNaming() Naming {
+	if r.namingFlag != nil {
+		return r.namingFlag
+	}
+
+	return DotCase
+}
+
+// envNaming returns the naming for environment variables.
+func (r *reader) envNaming() Naming {
+	if r.namingEnv != nil {
+		return r.namingEnv
+	}
+
+	return ScreamingSnakeCase
+}
+
+// fileEnvNaming returns the naming for file environment variables.
+// If no naming is set for file environment variables, the naming for environment variables is used.
+func (r *reader) fileEnvNaming() Naming {
+	if r.namingFileEnv != nil {
+		return r.namingFileEnv
+	}
+
+	return r.envNaming()
+}
+
+// tokenize breaks a field name into its words using the acronyms set using Acronyms option.
+// Longer acronyms are matched first (i.e. IPv4 before IP).
+func (r *reader) tokenize(name string) []string {
+	acronyms := append([]string{}, r.acronyms...)
+	sort.SliceStable(acronyms, func(i, j int) bool {
+		return utf8.RuneCountInString(acronyms[i]) > utf8.RuneCountInString(acronyms[j])
+	})
+
+	return tokenize(name, acronyms)
+}
+
+// getFlagName returns a canonical flag name for a field.
+//
+//	UserID       -->  user.id
+//	DatabaseURL  -->  database.url
+func (r *reader) getFlagName(name string) string {
+	return r.flagNaming().Name(r.tokenize(name))
+}
+
+// getEnvVarName returns a canonical environment variable name for a field.
+//
+//	UserID       -->  USER_ID
+//	DatabaseURL  -->  DATABASE_URL
+func (r *reader) getEnvVarName(name string) string {
+	return r.envNaming().Name(r.tokenize(name))
+}
+
+// getFileEnvVarName returns a canonical environment variable name for value file of a field.
+//
+//	UserID       -->  USER_ID_FILE
+//	DatabaseURL  -->  DATABASE_URL_FILE
+func (r *reader) getFileEnvVarName(name string) string {
+	return r.fileEnvNaming().Name(append(r.tokenize(name), "File"))
+}
+
+// elemIndex returns the index of an element of a list of structs from a name if the name starts with the name of the list
+// followed by an index and the name of a field (i.e. UPSTREAMS_0_HOST --> 0).
+func elemIndex(name, list string) (int, bool) {
+	rest := strings.TrimPrefix(name, list)
+	if rest == name {
+		return 0, false
+	}
+
+	rest = strings.TrimLeftFunc(rest, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	// The index should be followed by the name of a field
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+
+	if end <= 0 {
+		return 0, false
+	}
+
+	i, err := strconv.Atoi(rest[:end])
+	if err != nil || i >= maxElems {
+		return 0, false
+	}
+
+	return i, true
+}
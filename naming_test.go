@@ -0,0 +1,347 @@
+package konfig
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFlagName(t *testing.T) {
+	tests := []struct {
+		fieldName        string
+		expectedFlagName string
+	}{
+		{"c", "c"},
+		{"C", "c"},
+		{"camel", "camel"},
+		{"Camel", "camel"},
+		{"camelCase", "camel.case"},
+		{"CamelCase", "camel.case"},
+		{"OneTwoThree", "one.two.three"},
+		{"DatabaseURL", "database.url"},
+		{"DBEndpoints", "db.endpoints"},
+	}
+
+	r := &reader{}
+	for _, tc := range tests {
+		flagName := r.getFlagName(tc.fieldName)
+		assert.Equal(t, tc.expectedFlagName, flagName)
+	}
+}
+
+func TestGetEnvVarName(t *testing.T) {
+	tests := []struct {
+		fieldName          string
+		expectedEnvVarName string
+	}{
+		{"c", "C"},
+		{"C", "C"},
+		{"camel", "CAMEL"},
+		{"Camel", "CAMEL"},
+		{"camelCase", "CAMEL_CASE"},
+		{"CamelCase", "CAMEL_CASE"},
+		{"OneTwoThree", "ONE_TWO_THREE"},
+		{"DatabaseURL", "DATABASE_URL"},
+		{"DBEndpoints", "DB_ENDPOINTS"},
+	}
+
+	r := &reader{}
+	for _, tc := range tests {
+		envVarName := r.getEnvVarName(tc.fieldName)
+		assert.Equal(t, tc.expectedEnvVarName, envVarName)
+	}
+}
+
+func TestGetFileEnvVarName(t *testing.T) {
+	tests := []struct {
+		fieldName              string
+		expectedFileEnvVarName string
+	}{
+		{"c", "C_FILE"},
+		{"C", "C_FILE"},
+		{"camel", "CAMEL_FILE"},
+		{"Camel", "CAMEL_FILE"},
+		{"camelCase", "CAMEL_CASE_FILE"},
+		{"CamelCase", "CAMEL_CASE_FILE"},
+		{"OneTwoThree", "ONE_TWO_THREE_FILE"},
+		{"DatabaseURL", "DATABASE_URL_FILE"},
+		{"DBEndpoints", "DB_ENDPOINTS_FILE"},
+	}
+
+	r := &reader{}
+	for _, tc := range tests {
+		fileEnvVarName := r.getFileEnvVarName(tc.fieldName)
+		assert.Equal(t, tc.expectedFileEnvVarName, fileEnvVarName)
+	}
+}
+
+func TestReaderTokenize(t *testing.T) {
+	tests := []struct {
+		name             string
+		r                *reader
+		fieldName        string
+		expectedFlagName string
+		expectedEnvName  string
+	}{
+		{"OAuth", &reader{acronyms: DefaultAcronyms()}, "OAuth2ClientID", "oauth2.client.id", "OAUTH2_CLIENT_ID"},
+		{"OAuthWithoutDefaultAcronyms", &reader{}, "OAuth2ClientID", "o.auth2.client.id", "O_AUTH2_CLIENT_ID"},
+		{"S3", &reader{}, "S3Bucket", "s3.bucket", "S3_BUCKET"},
+		{"HTTP2", &reader{}, "HTTP2Enabled", "http2.enabled", "HTTP2_ENABLED"},
+		{"GraphQL", &reader{acronyms: DefaultAcronyms()}, "GraphQLEndpoint", "graphql.endpoint", "GRAPHQL_ENDPOINT"},
+		{"gRPC", &reader{acronyms: DefaultAcronyms()}, "ServergRPCPort", "server.grpc.port", "SERVER_GRPC_PORT"},
+		{"gRPCWithoutDefaultAcronyms", &reader{}, "ServergRPCPort", "serverg.rpc.port", "SERVERG_RPC_PORT"},
+		{"IPv6", &reader{acronyms: DefaultAcronyms()}, "ListenIPv6Address", "listen.ipv6.address", "LISTEN_IPV6_ADDRESS"},
+		{"IDs", &reader{}, "UserIDs", "user.ids", "USER_IDS"},
+		{"URLs", &reader{}, "BackendURLs", "backend.urls", "BACKEND_URLS"},
+		{"IPs", &reader{}, "ServerIPs", "server.ips", "SERVER_IPS"},
+		{"UUIDs", &reader{}, "UUIDs", "uuids", "UUIDS"},
+		{"MySQL", &reader{acronyms: DefaultAcronyms()}, "MySQLHost", "mysql.host", "MYSQL_HOST"},
+		{"MySQLWithoutDefaultAcronyms", &reader{}, "MySQLHost", "my.sql.host", "MY_SQL_HOST"},
+		{"V2", &reader{}, "V2API", "v2.api", "V2_API"},
+		{"K8s", &reader{}, "K8sNamespace", "k8s.namespace", "K8S_NAMESPACE"},
+		{"PostgreSQL", &reader{acronyms: DefaultAcronyms()}, "PostgreSQLHost", "postgresql.host", "POSTGRESQL_HOST"},
+		{"Unicode", &reader{}, "ÜberÄnderung", "über.änderung", "ÜBER_ÄNDERUNG"},
+		{"Underscore", &reader{}, "Log_Level", "log.level", "LOG_LEVEL"},
+		{"WithoutAcronym", &reader{}, "GitHubToken", "git.hub.token", "GIT_HUB_TOKEN"},
+		{"CustomAcronym", &reader{acronyms: []string{"GitHub"}}, "GitHubToken", "github.token", "GITHUB_TOKEN"},
+		{"CustomAcronymWithDigits", &reader{acronyms: []string{"K8s"}}, "K8sNamespace", "k8s.namespace", "K8S_NAMESPACE"},
+		{"KebabCase", &reader{namingFlag: KebabCase, acronyms: DefaultAcronyms()}, "OAuth2ClientID", "oauth2-client-id", "OAUTH2_CLIENT_ID"},
+		{"CamelCase", &reader{namingFlag: CamelCase, acronyms: DefaultAcronyms()}, "OAuth2ClientID", "oauth2ClientID", "OAUTH2_CLIENT_ID"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedFlagName, tc.r.getFlagName(tc.fieldName))
+			assert.Equal(t, tc.expectedEnvName, tc.r.getEnvVarName(tc.fieldName))
+		})
+	}
+}
+
+func TestCaseString(t *testing.T) {
+	tests := []struct {
+		c        Case
+		expected string
+	}{
+		{DotCase, "Dot"},
+		{KebabCase, "Kebab"},
+		{SnakeCase, "Snake"},
+		{ScreamingSnakeCase, "ScreamingSnake"},
+		{CamelCase, "Camel"},
+		{Case(99), "Case(99)"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.c.String())
+		})
+	}
+}
+
+func TestParseCase(t *testing.T) {
+	tests := []struct {
+		s            string
+		expectedCase Case
+		expectedOK   bool
+	}{
+		{"dot", DotCase, true},
+		{"Kebab", KebabCase, true},
+		{"snake", SnakeCase, true},
+		{"screaming_snake", ScreamingSnakeCase, true},
+		{"ScreamingSnake", ScreamingSnakeCase, true},
+		{"CAMEL", CamelCase, true},
+		{"pascal", DotCase, false},
+		{"", DotCase, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			c, ok := parseCase(tc.s)
+			assert.Equal(t, tc.expectedCase, c)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestCaseName(t *testing.T) {
+	tests := []struct {
+		words             []string
+		expectedDot       string
+		expectedKebab     string
+		expectedSnake     string
+		expectedScreaming string
+		expectedCamel     string
+	}{
+		{[]string{"Port"}, "port", "port", "port", "PORT", "port"},
+		{[]string{"log", "Level"}, "log.level", "log-level", "log_level", "LOG_LEVEL", "logLevel"},
+		{[]string{"Database", "URL"}, "database.url", "database-url", "database_url", "DATABASE_URL", "databaseURL"},
+		{[]string{"DB", "Endpoints"}, "db.endpoints", "db-endpoints", "db_endpoints", "DB_ENDPOINTS", "dbEndpoints"},
+		{[]string{"User", "ID", "File"}, "user.id.file", "user-id-file", "user_id_file", "USER_ID_FILE", "userIDFile"},
+	}
+
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.words, ""), func(t *testing.T) {
+			assert.Equal(t, tc.expectedDot, DotCase.Name(tc.words))
+			assert.Equal(t, tc.expectedKebab, KebabCase.Name(tc.words))
+			assert.Equal(t, tc.expectedSnake, SnakeCase.Name(tc.words))
+			assert.Equal(t, tc.expectedScreaming, ScreamingSnakeCase.Name(tc.words))
+			assert.Equal(t, tc.expectedCamel, CamelCase.Name(tc.words))
+		})
+	}
+}
+
+func TestFileSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        Naming
+		expected string
+	}{
+		{"Dot", DotCase, ".file"},
+		{"Kebab", KebabCase, "-file"},
+		{"Snake", SnakeCase, "_file"},
+		{"ScreamingSnake", ScreamingSnakeCase, "_FILE"},
+		{"Camel", CamelCase, "File"},
+		{"NamingFunc", NamingFunc(func(words []string) string { return strings.Join(words, "+") }), "+File"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fileSuffix(tc.n))
+		})
+	}
+}
+
+func TestElemIndex(t *testing.T) {
+	tests := []struct {
+		name          string
+		list          string
+		expectedIndex int
+		expectedOK    bool
+	}{
+		{"UPSTREAMS_0_HOST", "UPSTREAMS", 0, true},
+		{"upstreams.12.host", "upstreams", 12, true},
+		{"upstreams-3-host", "upstreams", 3, true},
+		{"upstreams0Host", "upstreams", 0, true},
+		{"UPSTREAMS_0", "UPSTREAMS", 0, false},
+		{"UPSTREAMS_HOST", "UPSTREAMS", 0, false},
+		{"UPSTREAMSX_0_HOST", "UPSTREAMS", 0, false},
+		{"UPSTREAMS_1000_HOST", "UPSTREAMS", 0, false},
+		{"BACKENDS_0_HOST", "UPSTREAMS", 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			i, ok := elemIndex(tc.name, tc.list)
+			assert.Equal(t, tc.expectedIndex, i)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestReaderNamings(t *testing.T) {
+	custom := NamingFunc(func(words []string) string {
+		return strings.ToLower(strings.Join(words, ":"))
+	})
+
+	tests := []struct {
+		name                string
+		r                   *reader
+		expectedFlagName    string
+		expectedEnvName     string
+		expectedFileEnvName string
+	}{
+		{"Default", &reader{}, "database.url", "DATABASE_URL", "DATABASE_URL_FILE"},
+		{"Kebab", &reader{namingFlag: KebabCase}, "database-url", "DATABASE_URL", "DATABASE_URL_FILE"},
+		{"EnvNaming", &reader{namingEnv: SnakeCase}, "database.url", "database_url", "database_url_file"},
+		{"FileEnvNaming", &reader{namingEnv: SnakeCase, namingFileEnv: CamelCase}, "database.url", "database_url", "databaseURLFile"},
+		{"NamingFunc", &reader{namingFlag: custom, namingEnv: custom}, "database:url", "database:url", "database:url:file"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedFlagName, tc.r.getFlagName("DatabaseURL"))
+			assert.Equal(t, tc.expectedEnvName, tc.r.getEnvVarName("DatabaseURL"))
+			assert.Equal(t, tc.expectedFileEnvName, tc.r.getFileEnvVarName("DatabaseURL"))
+		})
+	}
+}
+
+func TestReaderPlanNaming(t *testing.T) {
+	type fields struct {
+		LogLevel string
+	}
+
+	typ := reflect.TypeOf(fields{})
+
+	t.Run("Case", func(t *testing.T) {
+		r := &reader{namingFlag: KebabCase}
+		p := r.plan(typ)
+
+		assert.Equal(t, "log-level", p.fields[0].flagName)
+		assert.Same(t, p, r.plan(typ))
+		assert.NotSame(t, p, (&reader{}).plan(typ))
+		assert.NotSame(t, p, (&reader{namingFlag: KebabCase, acronyms: []string{"LogLevel"}}).plan(typ))
+	})
+
+	t.Run("NamingFunc", func(t *testing.T) {
+		r := &reader{
+			namingEnv: NamingFunc(func(words []string) string {
+				return "APP_" + ScreamingSnakeCase.Name(words)
+			}),
+		}
+		p := r.plan(typ)
+
+		// Plans are not cached for NamingFunc, since functions cannot be compared
+		assert.Equal(t, "APP_LOG_LEVEL", p.fields[0].envName)
+		assert.Equal(t, "APP_LOG_LEVEL_FILE", p.fields[0].fileEnvName)
+		assert.NotSame(t, p, r.plan(typ))
+	})
+}
+
+func TestPickNaming(t *testing.T) {
+	type backend struct {
+		Host string
+		Port int
+	}
+
+	type config struct {
+		NamingLogLevel string
+		NamingBackends []backend
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "--naming-log-level=debug", "--naming-backends-1-port=8081"}
+
+	envs := map[string]string{
+		"naming_backends_0_host": "10.0.0.1",
+		"naming_backends_1_host": "10.0.0.2",
+	}
+
+	for name, value := range envs {
+		err := os.Setenv(name, value)
+		assert.NoError(t, err)
+		defer os.Unsetenv(name)
+	}
+
+	l := NewLoader(FlagNaming(KebabCase), EnvNaming(SnakeCase), Strict())
+	c := &config{}
+	err := l.Pick(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &config{
+		NamingLogLevel: "debug",
+		NamingBackends: []backend{
+			{Host: "10.0.0.1"},
+			{Host: "10.0.0.2", Port: 8081},
+		},
+	}, c)
+	assert.Equal(t, []Field{
+		{Name: "NamingLogLevel", Type: "string", Flag: "naming-log-level", Env: "naming_log_level", FileEnv: "naming_log_level_file", Source: SourceFlag},
+		{Name: "NamingBackends", Type: "[]konfig.backend", Flag: "naming-backends", Env: "naming_backends", FileEnv: "naming_backends_file", Source: SourceEnv},
+	}, l.Describe())
+}
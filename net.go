@@ -0,0 +1,27 @@
+package konfig
+
+import (
+	"fmt"
+	"net"
+)
+
+// ParseIP parses an IPv4 or IPv6 address.
+// Unlike net.ParseIP, an error is returned for an invalid address.
+func ParseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %s", s)
+	}
+
+	return ip, nil
+}
+
+// ParseIPNet parses a network in CIDR notation (i.e. 192.168.0.0/16).
+func ParseIPNet(s string) (*net.IPNet, error) {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
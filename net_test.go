@@ -0,0 +1,57 @@
+package konfig
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             string
+		expectedIP    net.IP
+		expectedError error
+	}{
+		{"IPv4", "10.0.0.1", net.ParseIP("10.0.0.1"), nil},
+		{"IPv6", "fd00::1", net.ParseIP("fd00::1"), nil},
+		{"Invalid", "10.0.0.256", nil, errors.New("invalid IP address: 10.0.0.256")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ip, err := ParseIP(tc.s)
+
+			assert.Equal(t, tc.expectedIP, ip)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestParseIPNet(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             string
+		expectedIPNet *net.IPNet
+		expectedError string
+	}{
+		{"IPv4", "10.1.2.3/8", &net.IPNet{IP: net.IP{10, 0, 0, 0}, Mask: net.CIDRMask(8, 32)}, ""},
+		{"IPv6", "fd00::/8", &net.IPNet{IP: net.ParseIP("fd00::"), Mask: net.CIDRMask(8, 128)}, ""},
+		{"Invalid", "10.0.0.1", nil, "invalid CIDR address: 10.0.0.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := ParseIPNet(tc.s)
+
+			assert.Equal(t, tc.expectedIPNet, n)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package konfig
 
+import (
+	"os"
+	"time"
+)
+
 // Option sets optional parameters for reader.
 type Option func(*reader)
 
@@ -73,6 +78,51 @@ func PrefixFileEnv(prefix string) Option {
 	}
 }
 
+// FlagNaming is the option for making the names of command-line flags using a naming (i.e. KebabCase).
+// The default naming for flags is DotCase. Prefixes set using PrefixFlag option are added as they are.
+// You can also enable this option by setting KONFIG_FLAG_NAMING environment variable to dot, kebab, snake, screaming_snake, or camel.
+func FlagNaming(n Naming) Option {
+	return func(c *reader) {
+		c.namingFlag = n
+	}
+}
+
+// EnvNaming is the option for making the names of environment variables using a naming.
+// The default naming for environment variables is ScreamingSnakeCase.
+// This naming is also used for file environment variables if FileEnvNaming option is not set.
+// You can also enable this option by setting KONFIG_ENV_NAMING environment variable to dot, kebab, snake, screaming_snake, or camel.
+func EnvNaming(n Naming) Option {
+	return func(c *reader) {
+		c.namingEnv = n
+	}
+}
+
+// FileEnvNaming is the option for making the names of file environment variables using a naming.
+// The words passed to the naming end with File (i.e. DatabaseURL --> Database, URL, File).
+// You can also enable this option by setting KONFIG_FILE_ENV_NAMING environment variable to dot, kebab, snake, screaming_snake, or camel.
+func FileEnvNaming(n Naming) Option {
+	return func(c *reader) {
+		c.namingFileEnv = n
+	}
+}
+
+// DefaultAcronyms returns some common acronyms with mixed cases (OAuth, GraphQL, gRPC, IPv4, IPv6, MySQL, and PostgreSQL).
+// They are not registered by default, since they change the names of existing fields (i.e. MY_SQL_HOST becomes MYSQL_HOST).
+func DefaultAcronyms() []string {
+	return []string{"OAuth", "GraphQL", "gRPC", "IPv4", "IPv6", "MySQL", "PostgreSQL"}
+}
+
+// Acronyms is the option for keeping acronyms and initialisms with mixed cases as one word in the names of fields (i.e. OAuth or GraphQL).
+// Acronyms in upper-case (i.e. URL) do not need to be registered, since they are always kept as one word.
+// You can register the common acronyms using Acronyms(DefaultAcronyms()...).
+// You can also enable this option by setting KONFIG_ACRONYMS environment variable to a comma-separated list of acronyms.
+// In KONFIG_ACRONYMS, default stands for the common acronyms (i.e. KONFIG_ACRONYMS=default,GitHub).
+func Acronyms(acronyms ...string) Option {
+	return func(c *reader) {
+		c.acronyms = append(c.acronyms, acronyms...)
+	}
+}
+
 // Telepresence is the option for reading files when running in a Telepresence shell.
 // If the TELEPRESENCE_ROOT environment variable exist, files will be read from mounted volume.
 // See https://telepresence.io/howto/volumes.html for details.
@@ -81,3 +131,88 @@ func Telepresence() Option {
 		c.telepresence = true
 	}
 }
+
+// Polling is the option for watching configuration files by polling them on an interval instead of file system notifications.
+// The modification time, size, and content hash of files are checked for changes.
+// Polling is used automatically for files that cannot be watched using file system notifications.
+// You can also enable this option by setting KONFIG_POLL_INTERVAL environment variable to a duration.
+func Polling(interval time.Duration) Option {
+	return func(c *reader) {
+		c.pollInterval = interval
+	}
+}
+
+// ReloadOnSignal is the option for reading configuration files again every time the process receives any of the given signals.
+// If no signal is given, SIGHUP is used.
+// This option is only used by Watch and NewLive.
+func ReloadOnSignal(sigs ...os.Signal) Option {
+	return func(c *reader) {
+		if len(sigs) == 0 {
+			sigs = defaultReloadSignals
+		}
+		c.reloadSignals = sigs
+	}
+}
+
+// Backpressure is the option for bounding the number of pending updates for each subscriber.
+// Every subscriber receives its updates in order from a dedicated queue.
+// When a queue has size pending updates, the policy determines what happens to a new update.
+// By default, a queue keeps up to 64 pending updates and a new update replaces a pending update for the same field (BackpressureCoalesce).
+// If size is not positive, the default size is used.
+func Backpressure(policy BackpressurePolicy, size int) Option {
+	return func(c *reader) {
+		if size <= 0 {
+			size = defaultQueueSize
+		}
+		c.backpressure = policy
+		c.queueSize = size
+	}
+}
+
+// Metrics is the option for collecting statistics about delivering updates to subscribers.
+func Metrics(m *DeliveryMetrics) Option {
+	return func(c *reader) {
+		c.metrics = m
+	}
+}
+
+// Logging is the option for passing logs to a logger instead of the standard log package.
+// The logger receives all logs with their verbosity levels and pairs of keys and values (i.e. field, source, and path).
+// Debug option and KONFIG_DEBUG environment variable only control the logs written using the standard log package.
+func Logging(logger Logger) Option {
+	return func(c *reader) {
+		c.logger = logger
+	}
+}
+
+// FileTrim is the option for specifying how the contents of files are trimmed before being used as values.
+// By default, a single trailing newline is removed.
+// You can also set this option using KONFIG_FILE_TRIM environment variable (newline, space, or none).
+func FileTrim(mode TrimMode) Option {
+	return func(c *reader) {
+		c.fileTrim = mode
+	}
+}
+
+// Strict is the option for returning an error for exported fields that cannot be read (i.e. maps without format tag).
+// The error lists every such field and the reason it cannot be read.
+// You can opt a field out of reading values by setting `konfig` struct tag to `-`.
+// In strict mode, an error is also returned for environment variables with the prefixes set by PrefixEnv and PrefixFileEnv
+// and command-line flags that do not belong to any field with suggestions for similar names.
+// Flags defined using the flag package are not reported, so they should be defined before reading values.
+// You can also enable this option by setting KONFIG_STRICT environment variable to true.
+func Strict() Option {
+	return func(c *reader) {
+		c.strict = true
+	}
+}
+
+// Expand is the option for expanding references to environment variables and other fields in values.
+// $VAR, ${VAR}, and ${VAR:-default} are replaced by values of environment variables
+// and ${.Field} is replaced by the value of another field. $$ can be used for a single $.
+// You can also enable this option by setting KONFIG_EXPAND environment variable to true.
+func Expand() Option {
+	return func(c *reader) {
+		c.expand = true
+	}
+}
@@ -1,7 +1,11 @@
 package konfig
 
 import (
+	"log/slog"
+	"os"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -94,6 +98,58 @@ func TestPrefixFileEnv(t *testing.T) {
 	assert.Equal(t, expected, r)
 }
 
+func TestFlagNaming(t *testing.T) {
+	r := new(reader)
+	FlagNaming(KebabCase)(r)
+
+	expected := &reader{
+		namingFlag: KebabCase,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestEnvNaming(t *testing.T) {
+	r := new(reader)
+	EnvNaming(SnakeCase)(r)
+
+	expected := &reader{
+		namingEnv: SnakeCase,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestFileEnvNaming(t *testing.T) {
+	r := new(reader)
+	FileEnvNaming(CamelCase)(r)
+
+	expected := &reader{
+		namingFileEnv: CamelCase,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestDefaultAcronyms(t *testing.T) {
+	acronyms := DefaultAcronyms()
+	acronyms[0] = "GitHub"
+
+	assert.Equal(t, []string{"OAuth", "GraphQL", "gRPC", "IPv4", "IPv6", "MySQL", "PostgreSQL"}, DefaultAcronyms())
+}
+
+func TestAcronyms(t *testing.T) {
+	r := new(reader)
+	Acronyms("GitHub", "K8s")(r)
+	Acronyms("OpenAPI")(r)
+
+	expected := &reader{
+		acronyms: []string{"GitHub", "K8s", "OpenAPI"},
+	}
+
+	assert.Equal(t, expected, r)
+}
+
 func TestTelepresence(t *testing.T) {
 	r := new(reader)
 	Telepresence()(r)
@@ -104,3 +160,121 @@ func TestTelepresence(t *testing.T) {
 
 	assert.Equal(t, expected, r)
 }
+
+func TestPolling(t *testing.T) {
+	r := new(reader)
+	Polling(time.Second)(r)
+
+	expected := &reader{
+		pollInterval: time.Second,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		r := new(reader)
+		ReloadOnSignal()(r)
+
+		expected := &reader{
+			reloadSignals: []os.Signal{syscall.SIGHUP},
+		}
+
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		r := new(reader)
+		ReloadOnSignal(syscall.SIGHUP, os.Interrupt)(r)
+
+		expected := &reader{
+			reloadSignals: []os.Signal{syscall.SIGHUP, os.Interrupt},
+		}
+
+		assert.Equal(t, expected, r)
+	})
+}
+
+func TestBackpressure(t *testing.T) {
+	t.Run("DefaultSize", func(t *testing.T) {
+		r := new(reader)
+		Backpressure(BackpressureBlock, 0)(r)
+
+		expected := &reader{
+			backpressure: BackpressureBlock,
+			queueSize:    64,
+		}
+
+		assert.Equal(t, expected, r)
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		r := new(reader)
+		Backpressure(BackpressureDropOldest, 10)(r)
+
+		expected := &reader{
+			backpressure: BackpressureDropOldest,
+			queueSize:    10,
+		}
+
+		assert.Equal(t, expected, r)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	m := new(DeliveryMetrics)
+	r := new(reader)
+	Metrics(m)(r)
+
+	expected := &reader{
+		metrics: m,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestFileTrim(t *testing.T) {
+	r := new(reader)
+	FileTrim(TrimSpace)(r)
+
+	expected := &reader{
+		fileTrim: TrimSpace,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestExpand(t *testing.T) {
+	r := new(reader)
+	Expand()(r)
+
+	expected := &reader{
+		expand: true,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestStrict(t *testing.T) {
+	r := new(reader)
+	Strict()(r)
+
+	expected := &reader{
+		strict: true,
+	}
+
+	assert.Equal(t, expected, r)
+}
+
+func TestLogging(t *testing.T) {
+	logger := NewSlogLogger(slog.Default())
+	r := new(reader)
+	Logging(logger)(r)
+
+	expected := &reader{
+		logger: logger,
+	}
+
+	assert.Equal(t, expected, r)
+}
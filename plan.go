@@ -0,0 +1,228 @@
+package konfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldPlan has all the information for reading a struct field that does not change from one load to another.
+type fieldPlan struct {
+	index       int
+	name        string
+	typ         string
+	dataType    string
+	isBool      bool
+	flagName    string
+	envName     string
+	fileEnvName string
+	aliases     aliases
+	listSep     string
+	encoding    string
+	layout      string
+	unit        string
+	format      string
+	binary      bool
+	structList  bool
+}
+
+// structPlan is the list of fields that can be read for a struct type.
+type structPlan struct {
+	fields      []fieldPlan
+	unsupported []unsupportedField
+	invalid     []string // fields with invalid tags
+}
+
+// planKey identifies a plan by a struct type and the options affecting the names and separators of its fields.
+type planKey struct {
+	typ           reflect.Type
+	listSep       string
+	prefixFlag    string
+	prefixEnv     string
+	prefixFileEnv string
+	namingFlag    Naming
+	namingEnv     Naming
+	namingFileEnv Naming
+	acronyms      string
+}
+
+// fieldPlanKey identifies the plan for a field registered with a lookup by its name and tag and the options affecting its names.
+type fieldPlanKey struct {
+	planKey
+	name string
+	tag  reflect.StructTag
+}
+
+// plans caches the plans for struct types, so struct fields are walked with reflection only once per type.
+var plans sync.Map // planKey --> *structPlan
+
+// fieldPlans caches the plans for fields registered with lookups, so the names for fields are worked out only once
+// for the code generated by konfig-gen too.
+var fieldPlans sync.Map // fieldPlanKey --> fieldPlan
+
+// planKey returns the key for caching the plan for a struct type.
+// Plans are not cached for namings created using NamingFunc, since they cannot be used as keys.
+func (r *reader) planKey(t reflect.Type) (planKey, bool) {
+	if !isNamingComparable(r.namingFlag) || !isNamingComparable(r.namingEnv) || !isNamingComparable(r.namingFileEnv) {
+		return planKey{}, false
+	}
+
+	return planKey{
+		typ:           t,
+		listSep:       r.listSep,
+		prefixFlag:    r.prefixFlag,
+		prefixEnv:     r.prefixEnv,
+		prefixFileEnv: r.prefixFileEnv,
+		namingFlag:    r.namingFlag,
+		namingEnv:     r.namingEnv,
+		namingFileEnv: r.namingFileEnv,
+		acronyms:      strings.Join(r.acronyms, ","),
+	}, true
+}
+
+// plan returns the cached plan for a struct type or builds a new one.
+func (r *reader) plan(t reflect.Type) *structPlan {
+	key, ok := r.planKey(t)
+	if !ok {
+		return r.buildPlan(t)
+	}
+
+	if p, ok := plans.Load(key); ok {
+		return p.(*structPlan)
+	}
+
+	p, _ := plans.LoadOrStore(key, r.buildPlan(t))
+	return p.(*structPlan)
+}
+
+// fieldPlan returns the cached plan for a field registered with a lookup or works out a new one.
+func (r *reader) fieldPlan(name string, tag reflect.StructTag) fieldPlan {
+	pk, ok := r.planKey(nil)
+	if !ok {
+		return r.newFieldPlan(name, tag)
+	}
+
+	key := fieldPlanKey{pk, name, tag}
+	if p, ok := fieldPlans.Load(key); ok {
+		return p.(fieldPlan)
+	}
+
+	p, _ := fieldPlans.LoadOrStore(key, r.newFieldPlan(name, tag))
+	return p.(fieldPlan)
+}
+
+// buildPlan walks the fields of a struct type and works out the names and separators for them.
+func (r *reader) buildPlan(t reflect.Type) *structPlan {
+	p := &structPlan{}
+
+	// Iterate over struct fields
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i) // reflect.StructField --> f.Name, f.Type.Name(), f.Type.Kind(), f.Tag.Get(tag)
+
+		// Skip unexported and ignored fields
+		if !f.IsExported() || isIgnored(f) {
+			continue
+		}
+
+		// Skip fields with unknown formats, so a typo in format tag is not silently ignored
+		if format := f.Tag.Get(tagFormat); format != "" && !isFormat(format) {
+			p.invalid = append(p.invalid, fmt.Sprintf("%s: unknown format: %s", f.Name, format))
+			continue
+		}
+
+		// Skip fields with unknown encodings, so a typo in encoding tag is not only reported when a value is set
+		if encoding := f.Tag.Get(tagEncoding); encoding != "" && !isEncoding(encoding) {
+			p.invalid = append(p.invalid, fmt.Sprintf("%s: unknown encoding: %s", f.Name, encoding))
+			continue
+		}
+
+		// Skip fields with unknown units, so a typo in unit tag is not only reported when a value is set
+		if unit := f.Tag.Get(tagUnit); unit != "" && !isUnit(unit) {
+			p.invalid = append(p.invalid, fmt.Sprintf("%s: unknown unit: %s", f.Name, unit))
+			continue
+		}
+
+		// Skip unsupported fields
+		// Fields of any type can be read using format tag (i.e. maps and nested structs).
+		if !isTypeSupported(f.Type) && f.Tag.Get(tagFormat) == "" {
+			if isUnsupported(f) {
+				p.unsupported = append(p.unsupported, unsupportedField{f.Name, f.Type.String(), unsupportedReason(f.Type)})
+			}
+			continue
+		}
+
+		dataType := f.Type.String()
+		if _, ok := textTypes[f.Type]; !ok && f.Type.Kind() == reflect.Slice {
+			dataType = fmt.Sprintf("[]%s", f.Type.Elem())
+		}
+
+		fp := r.newFieldPlan(f.Name, f.Tag)
+		fp.index = i
+		fp.typ = f.Type.String()
+		fp.dataType = dataType
+		fp.isBool = f.Type.Kind() == reflect.Bool
+		fp.binary = isBinary(f.Type, f.Tag)
+		fp.structList = fp.format == "" && isStructList(f.Type)
+
+		p.fields = append(p.fields, fp)
+	}
+
+	return p
+}
+
+// isBinary determines whether or not a field is a binary field.
+// A []byte field is read as raw bytes unless a list separator is explicitly specified for it.
+func isBinary(t reflect.Type, tag reflect.StructTag) bool {
+	// net.IP is a []byte too
+	if _, ok := textTypes[t]; ok {
+		return false
+	}
+
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && tag.Get(tagSep) == "" && tag.Get(tagFormat) == ""
+}
+
+// newFieldPlan works out the names of the flag and environment variables and the list separator for a field.
+func (r *reader) newFieldPlan(name string, tag reflect.StructTag) fieldPlan {
+	// `flag:"..."`
+	flagName, flagAliases := splitNames(tag.Get(tagFlag))
+	if flagName == "" {
+		flagName = r.prefixFlag + r.getFlagName(name)
+	}
+
+	// `env:"..."`
+	envName, envAliases := splitNames(tag.Get(tagEnv))
+	if envName == "" {
+		envName = r.prefixEnv + r.getEnvVarName(name)
+	}
+
+	// `fileenv:"..."`
+	fileEnvName, fileEnvAliases := splitNames(tag.Get(tagFileEnv))
+	if fileEnvName == "" {
+		fileEnvName = r.prefixFileEnv + r.getFileEnvVarName(name)
+	}
+
+	// `sep:"..."`
+	listSep := tag.Get(tagSep)
+	if listSep == "" {
+		listSep = r.listSep
+	}
+
+	return fieldPlan{
+		name:        name,
+		flagName:    flagName,
+		envName:     envName,
+		fileEnvName: fileEnvName,
+		listSep:     listSep,
+		encoding:    tag.Get(tagEncoding),
+		layout:      tag.Get(tagLayout),
+		unit:        tag.Get(tagUnit),
+		format:      tag.Get(tagFormat),
+		aliases: aliases{
+			flag:       flagAliases,
+			env:        envAliases,
+			fileEnv:    fileEnvAliases,
+			deprecated: splitList(tag.Get(tagDeprecated)),
+		},
+	}
+}
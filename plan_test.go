@@ -0,0 +1,151 @@
+package konfig
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBinary(t *testing.T) {
+	type fields struct {
+		Bytes    []byte
+		Uint8s   []uint8
+		List     []byte `sep:","`
+		Raw      json.RawMessage
+		String   string
+		Uint16s  []uint16
+		BytesPtr *[]byte
+		IP       net.IP
+		JSON     []byte `format:"json"`
+	}
+
+	tests := []struct {
+		field    string
+		expected bool
+	}{
+		{"Bytes", true},
+		{"Uint8s", true},
+		{"List", false},
+		{"Raw", true},
+		{"String", false},
+		{"Uint16s", false},
+		{"BytesPtr", false},
+		{"IP", false},
+		{"JSON", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.field, func(t *testing.T) {
+			f, _ := reflect.TypeOf(fields{}).FieldByName(tc.field)
+			assert.Equal(t, tc.expected, isBinary(f.Type, f.Tag))
+		})
+	}
+}
+
+func TestReaderPlan(t *testing.T) {
+	type fields struct {
+		unexported  string
+		Unsupported chan int
+		LogLevel    string
+		Ports       []int          `sep:"|"`
+		Token       string         `flag:"-" env:"API_TOKEN"`
+		Routes      map[string]int `format:"json"`
+		Unformatted map[string]int
+		Ignored     map[string]int `konfig:"-"`
+		Timeout     string         `flag:",t" env:"TIMEOUT,OLD_TIMEOUT" deprecated:"OLD_TIMEOUT"`
+		Misformat   map[string]int `format:"xml"`
+		Misencoded  []byte         `encoding:"b64"`
+		Misunit     int64          `unit:"byte"`
+	}
+
+	tests := []struct {
+		name     string
+		r        *reader
+		expected *structPlan
+	}{
+		{
+			name: "Default",
+			r: &reader{
+				listSep: ",",
+			},
+			expected: &structPlan{
+				fields: []fieldPlan{
+					{index: 2, name: "LogLevel", typ: "string", dataType: "string", flagName: "log.level", envName: "LOG_LEVEL", fileEnvName: "LOG_LEVEL_FILE", listSep: ","},
+					{index: 3, name: "Ports", typ: "[]int", dataType: "[]int", flagName: "ports", envName: "PORTS", fileEnvName: "PORTS_FILE", listSep: "|"},
+					{index: 4, name: "Token", typ: "string", dataType: "string", flagName: "-", envName: "API_TOKEN", fileEnvName: "TOKEN_FILE", listSep: ","},
+					{index: 5, name: "Routes", typ: "map[string]int", dataType: "map[string]int", flagName: "routes", envName: "ROUTES", fileEnvName: "ROUTES_FILE", listSep: ",", format: "json"},
+					{index: 8, name: "Timeout", typ: "string", dataType: "string", flagName: "timeout", envName: "TIMEOUT", fileEnvName: "TIMEOUT_FILE", listSep: ",", aliases: aliases{flag: []string{"t"}, env: []string{"OLD_TIMEOUT"}, deprecated: []string{"OLD_TIMEOUT"}}},
+				},
+				unsupported: []unsupportedField{
+					{"Unsupported", "chan int", "chan values are not supported"},
+					{"Unformatted", "map[string]int", "maps can only be read using format tag"},
+				},
+				invalid: []string{
+					"Misformat: unknown format: xml",
+					"Misencoded: unknown encoding: b64",
+					"Misunit: unknown unit: byte",
+				},
+			},
+		},
+		{
+			name: "WithOptions",
+			r: &reader{
+				listSep:       ";",
+				prefixFlag:    "config.",
+				prefixEnv:     "CONFIG_",
+				prefixFileEnv: "CONFIG_",
+			},
+			expected: &structPlan{
+				fields: []fieldPlan{
+					{index: 2, name: "LogLevel", typ: "string", dataType: "string", flagName: "config.log.level", envName: "CONFIG_LOG_LEVEL", fileEnvName: "CONFIG_LOG_LEVEL_FILE", listSep: ";"},
+					{index: 3, name: "Ports", typ: "[]int", dataType: "[]int", flagName: "config.ports", envName: "CONFIG_PORTS", fileEnvName: "CONFIG_PORTS_FILE", listSep: "|"},
+					{index: 4, name: "Token", typ: "string", dataType: "string", flagName: "-", envName: "API_TOKEN", fileEnvName: "CONFIG_TOKEN_FILE", listSep: ";"},
+					{index: 5, name: "Routes", typ: "map[string]int", dataType: "map[string]int", flagName: "config.routes", envName: "CONFIG_ROUTES", fileEnvName: "CONFIG_ROUTES_FILE", listSep: ";", format: "json"},
+					{index: 8, name: "Timeout", typ: "string", dataType: "string", flagName: "config.timeout", envName: "TIMEOUT", fileEnvName: "CONFIG_TIMEOUT_FILE", listSep: ";", aliases: aliases{flag: []string{"t"}, env: []string{"OLD_TIMEOUT"}, deprecated: []string{"OLD_TIMEOUT"}}},
+				},
+				unsupported: []unsupportedField{
+					{"Unsupported", "chan int", "chan values are not supported"},
+					{"Unformatted", "map[string]int", "maps can only be read using format tag"},
+				},
+				invalid: []string{
+					"Misformat: unknown format: xml",
+					"Misencoded: unknown encoding: b64",
+					"Misunit: unknown unit: byte",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			typ := reflect.TypeOf(fields{})
+
+			p := tc.r.plan(typ)
+			assert.Equal(t, tc.expected, p)
+
+			// The same plan is returned for the same type and options
+			assert.Same(t, p, tc.r.plan(typ))
+		})
+	}
+}
+
+func TestReaderFieldPlan(t *testing.T) {
+	r := &reader{listSep: ",", prefixEnv: "APP_"}
+	p := r.fieldPlan("LogLevel", `sep:"|"`)
+
+	assert.Equal(t, fieldPlan{name: "LogLevel", flagName: "log.level", envName: "APP_LOG_LEVEL", fileEnvName: "LOG_LEVEL_FILE", listSep: "|"}, p)
+
+	key, ok := r.planKey(nil)
+	assert.True(t, ok)
+	_, ok = fieldPlans.Load(fieldPlanKey{key, "LogLevel", `sep:"|"`})
+	assert.True(t, ok)
+
+	// Plans are not cached for namings created using NamingFunc
+	r.namingEnv = NamingFunc(func(words []string) string { return "X" })
+	_, ok = r.planKey(nil)
+	assert.False(t, ok)
+	assert.Equal(t, "APP_X", r.fieldPlan("LogLevel", "").envName)
+}
@@ -1,22 +1,34 @@
 package konfig
 
 import (
+	"errors"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // fieldInfo has all the information for setting a struct field later.
 type fieldInfo struct {
-	value   reflect.Value
-	name    string
-	listSep string
+	value    reflect.Value
+	name     string
+	listSep  string
+	encoding string
+	layout   string
+	unit     string
+	format   string
+	binary   bool
+	from     string      // where the value is read from (only used for reporting errors)
+	ref      string      // the value referring to the file (i.e. paths to certificate files)
+	list     *fieldPlan  // the plan for a list of structs
+	indexed  bool        // whether or not a list of structs is read from indexed names
+	elems    []elemValue // the values read for the elements of a list of structs (read again if nil)
 }
 
 // reader controls how configuration values are read.
@@ -29,10 +41,32 @@ type reader struct {
 	prefixFlag    string
 	prefixEnv     string
 	prefixFileEnv string
+	namingFlag    Naming
+	namingEnv     Naming
+	namingFileEnv Naming
+	acronyms      []string
 	telepresence  bool
-
+	pollInterval  time.Duration
+	reloadSignals []os.Signal
+	backpressure  BackpressurePolicy
+	queueSize     int
+	metrics       *DeliveryMetrics
+	logger        Logger
+	expand        bool
+	fileTrim      TrimMode
+	strict        bool
+
+	args          flagArgs
 	subscribers   []chan Update
+	queues        []*queue[Update]
+	applyMu       sync.Mutex // serializes applying new values and guards deferring and deferred
+	deferring     bool
+	deferred      []Update
 	filesToFields map[string]fieldInfo
+	fieldsMu      sync.Mutex // guards fields, values, and secrets
+	fields        []Field
+	values        map[string]string
+	secrets       map[string]bool // fields whose values are not logged
 }
 
 // readerFromEnv creates a new reader with defaults and with options read from environment variables.
@@ -71,11 +105,53 @@ func readerFromEnv() *reader {
 	prefixEnv := os.Getenv(envPrefixEnv)
 	prefixFileEnv := os.Getenv(envPrefixFileEnv)
 
+	var namingFlag, namingEnv, namingFileEnv Naming
+	if c, ok := parseCase(os.Getenv(envFlagNaming)); ok {
+		namingFlag = c
+	}
+	if c, ok := parseCase(os.Getenv(envEnvNaming)); ok {
+		namingEnv = c
+	}
+	if c, ok := parseCase(os.Getenv(envFileEnvNaming)); ok {
+		namingFileEnv = c
+	}
+
+	var acronyms []string
+	if str := os.Getenv(envAcronyms); str != "" {
+		for _, a := range splitList(str) {
+			if a == "default" {
+				acronyms = append(acronyms, DefaultAcronyms()...)
+			} else {
+				acronyms = append(acronyms, a)
+			}
+		}
+	}
+
 	var telepresence bool
 	if str := os.Getenv(envTelepresence); str != "" {
 		telepresence, _ = strconv.ParseBool(str)
 	}
 
+	var pollInterval time.Duration
+	if str := os.Getenv(envPollInterval); str != "" {
+		pollInterval, _ = time.ParseDuration(str)
+	}
+
+	var expand bool
+	if str := os.Getenv(envExpand); str != "" {
+		expand, _ = strconv.ParseBool(str)
+	}
+
+	var fileTrim TrimMode
+	if str := os.Getenv(envFileTrim); str != "" {
+		fileTrim, _ = parseTrimMode(str)
+	}
+
+	var strict bool
+	if str := os.Getenv(envStrict); str != "" {
+		strict, _ = strconv.ParseBool(str)
+	}
+
 	return &reader{
 		debug:         debug,
 		listSep:       listSep,
@@ -85,13 +161,36 @@ func readerFromEnv() *reader {
 		prefixFlag:    prefixFlag,
 		prefixEnv:     prefixEnv,
 		prefixFileEnv: prefixFileEnv,
+		namingFlag:    namingFlag,
+		namingEnv:     namingEnv,
+		namingFileEnv: namingFileEnv,
+		acronyms:      acronyms,
 		telepresence:  telepresence,
+		pollInterval:  pollInterval,
+		expand:        expand,
+		fileTrim:      fileTrim,
+		strict:        strict,
 
 		subscribers:   nil,
 		filesToFields: map[string]fieldInfo{},
 	}
 }
 
+// newReader creates a new reader with options read from environment variables and the given options.
+func newReader(subscribers []chan Update, opts ...Option) *reader {
+	r := readerFromEnv()
+	r.subscribers = subscribers
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.logLine(2)
+	r.log(2, "options loaded", "options", r.String())
+	r.logLine(2)
+
+	return r
+}
+
 // String is used for printing debugging information.
 // The output should fit in one line.
 func (r *reader) String() string {
@@ -129,10 +228,58 @@ func (r *reader) String() string {
 		strs = append(strs, fmt.Sprintf("PrefixFileEnv<%s>", r.prefixFileEnv))
 	}
 
+	if r.namingFlag != nil {
+		strs = append(strs, fmt.Sprintf("FlagNaming<%s>", namingString(r.namingFlag)))
+	}
+
+	if r.namingEnv != nil {
+		strs = append(strs, fmt.Sprintf("EnvNaming<%s>", namingString(r.namingEnv)))
+	}
+
+	if r.namingFileEnv != nil {
+		strs = append(strs, fmt.Sprintf("FileEnvNaming<%s>", namingString(r.namingFileEnv)))
+	}
+
+	if len(r.acronyms) > 0 {
+		strs = append(strs, fmt.Sprintf("Acronyms<%s>", strings.Join(r.acronyms, ",")))
+	}
+
 	if r.telepresence {
 		strs = append(strs, "Telepresence")
 	}
 
+	if r.pollInterval > 0 {
+		strs = append(strs, fmt.Sprintf("PollInterval<%s>", r.pollInterval))
+	}
+
+	if len(r.reloadSignals) > 0 {
+		sigs := make([]string, len(r.reloadSignals))
+		for i, sig := range r.reloadSignals {
+			sigs[i] = sig.String()
+		}
+		strs = append(strs, fmt.Sprintf("ReloadSignals<%s>", strings.Join(sigs, ",")))
+	}
+
+	if r.queueSize > 0 {
+		strs = append(strs, fmt.Sprintf("Backpressure<%s,%d>", r.backpressure, r.queueSize))
+	}
+
+	if r.logger != nil {
+		strs = append(strs, "Logger")
+	}
+
+	if r.expand {
+		strs = append(strs, "Expand")
+	}
+
+	if r.fileTrim != TrimNewline {
+		strs = append(strs, fmt.Sprintf("FileTrim<%s>", r.fileTrim))
+	}
+
+	if r.strict {
+		strs = append(strs, "Strict")
+	}
+
 	if len(r.subscribers) > 0 {
 		strs = append(strs, fmt.Sprintf("Subscribers<%d>", len(r.subscribers)))
 	}
@@ -140,37 +287,152 @@ func (r *reader) String() string {
 	return strings.Join(strs, " + ")
 }
 
-func (r *reader) log(verbosity uint, msg string, args ...interface{}) {
-	if verbosity <= r.debug {
-		log.Printf(msg+"\n", args...)
+// log passes a message with pairs of keys and values to the logger.
+// If no logger is set, messages are written using the standard log package up to the debug verbosity level.
+// The values of secret fields are replaced by their lengths.
+func (r *reader) log(verbosity uint, msg string, keyvals ...interface{}) {
+	if r.logger != nil {
+		r.logger.Log(verbosity, msg, r.redact(keyvals)...)
+	} else if verbosity <= r.debug {
+		stdLogger{}.Log(verbosity, msg, r.redact(keyvals)...)
 	}
 }
 
+// markSecret marks a field whose values should not be logged.
+// Files usually have secrets, so fields read from files and binary fields are marked.
+func (r *reader) markSecret(name string) {
+	r.fieldsMu.Lock()
+	defer r.fieldsMu.Unlock()
+
+	if r.secrets == nil {
+		r.secrets = map[string]bool{}
+	}
+	r.secrets[name] = true
+}
+
+// isSecret determines whether or not the values of a field should not be logged.
+func (r *reader) isSecret(name interface{}) bool {
+	s, ok := name.(string)
+	if !ok {
+		return false
+	}
+
+	r.fieldsMu.Lock()
+	defer r.fieldsMu.Unlock()
+
+	return r.secrets[s]
+}
+
+// redact replaces the value in pairs of keys and values with its length if the field is secret.
+func (r *reader) redact(keyvals []interface{}) []interface{} {
+	var field interface{}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == "field" {
+			field = keyvals[i+1]
+		}
+	}
+
+	if !r.isSecret(field) {
+		return keyvals
+	}
+
+	redacted := make([]interface{}, len(keyvals))
+	copy(redacted, keyvals)
+
+	for i := 0; i+1 < len(redacted); i += 2 {
+		if redacted[i] == "value" {
+			redacted[i], redacted[i+1] = "length", valueLength(redacted[i+1])
+		}
+	}
+
+	return redacted
+}
+
+// valueLength returns the length of a value for logging instead of the value.
+// The length of a list is the number of its items.
+func valueLength(val interface{}) int {
+	if s, ok := val.(string); ok {
+		return len(s)
+	}
+
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len()
+	}
+
+	return len(fmt.Sprint(val))
+}
+
+// logLine writes a separator line, so the logs written using the standard log package are more readable.
+func (r *reader) logLine(verbosity uint) {
+	if r.logger == nil && verbosity <= r.debug {
+		log.Println(line)
+	}
+}
+
+// getFlagValue returns the value set for a flag from the parsed command-line arguments.
+// If the command-line arguments are not parsed yet, they will be parsed for the flag.
+func (r *reader) getFlagValue(flagName string) string {
+	if r.args == nil {
+		return getFlagValue(flagName)
+	}
+	return r.args[flagName]
+}
+
 // getFieldValue reads and returns the string value for a field from either
 //   - command-line flags,
 //   - environment variables,
 //   - or configuration files
-// If the value is read from a file, the second returned value will be the file path.
-func (r *reader) getFieldValue(fieldName, flagName, envName, fileEnvName string) (string, string) {
-	var value, filePath string
+// The second returned value is the source that the value is read from.
+// If a file is specified for the field, the third returned value will be the file path.
+// The fourth returned value is the name of the flag or environment variable that the value is read from,
+// which can be an alias for the field.
+func (r *reader) getFieldValue(p *fieldPlan) (string, Source, string, string) {
+	fieldName := p.name
+
+	if p.binary {
+		r.markSecret(fieldName)
+	}
+
+	var value, filePath, name string
+	source := SourceDefault
 
 	// First, try reading from flag
-	if value == "" && flagName != skip && !r.skipFlag {
-		value = getFlagValue(flagName)
-		r.log(5, "[%s] value read from flag %s: %s", fieldName, flagName, value)
+	if value == "" && p.flagName != skip && !r.skipFlag {
+		for _, flagName := range p.flagNames() {
+			value = r.getFlagValue(flagName)
+			r.log(5, "value read from flag", "field", fieldName, "source", SourceFlag, "flag", flagName, "value", value)
+			if value != "" {
+				source, name = SourceFlag, flagName
+				break
+			}
+		}
 	}
 
 	// Second, try reading from environment variable
-	if value == "" && envName != skip && !r.skipEnv {
-		value = os.Getenv(envName)
-		r.log(5, "[%s] value read from environment variable %s: %s", fieldName, envName, value)
+	if value == "" && p.envName != skip && !r.skipEnv {
+		for _, envName := range p.envNames() {
+			value = os.Getenv(envName)
+			r.log(5, "value read from environment variable", "field", fieldName, "source", SourceEnv, "env", envName, "value", value)
+			if value != "" {
+				source, name = SourceEnv, envName
+				break
+			}
+		}
 	}
 
 	// Third, try reading from file
-	if value == "" && fileEnvName != skip && !r.skipFileEnv {
+	if value == "" && p.fileEnvName != skip && !r.skipFileEnv {
 		// Read file environment variable
-		filePath = os.Getenv(fileEnvName)
-		r.log(5, "[%s] value read from file environment variable %s: %s", fieldName, fileEnvName, filePath)
+		for _, fileEnvName := range p.fileEnvNames() {
+			filePath = os.Getenv(fileEnvName)
+			r.log(5, "value read from file environment variable", "field", fieldName, "env", fileEnvName, "path", filePath)
+			if filePath != "" {
+				name = fileEnvName
+				break
+			}
+		}
 
 		if filePath != "" {
 			// Check for Telepresence
@@ -178,157 +440,434 @@ func (r *reader) getFieldValue(fieldName, flagName, envName, fileEnvName string)
 			if r.telepresence {
 				if mountPath := os.Getenv(envTelepresenceRoot); mountPath != "" {
 					filePath = filepath.Join(mountPath, filePath)
-					r.log(5, "[%s] telepresence mount path: %s", fieldName, mountPath)
+					r.log(5, "telepresence mount path", "field", fieldName, "path", mountPath)
 				}
 			}
 
 			// Read config file
 			filePath = filepath.Clean(filePath)
-			if b, err := ioutil.ReadFile(filePath); err == nil {
-				value = string(b)
-				r.log(5, "[%s] value read from %s: %s", fieldName, filePath, value)
+			if b, err := r.readFile(filePath, p.binary); err == nil {
+				value = b
+				r.markSecret(fieldName)
+				// Files usually have secrets, so their contents are not logged
+				r.log(5, "value read from file", "field", fieldName, "source", SourceFile, "path", filePath, "length", len(value))
+				if value != "" {
+					source = SourceFile
+				}
 			}
 		}
 	}
 
-	return value, filePath
+	// Deprecated names are still read, but they should be replaced
+	if name != "" && p.aliases.isDeprecated(name) {
+		r.log(1, "deprecated name used", "field", fieldName, "name", name)
+	}
+
+	return value, source, filePath, name
+}
+
+// startQueues creates a delivery queue for every subscriber channel.
+func (r *reader) startQueues() {
+	policy, size := r.deliveryPolicy()
+	r.queues = make([]*queue[Update], len(r.subscribers))
+	for i, sub := range r.subscribers {
+		r.queues[i] = newQueue(i, sub, updateKey, policy, size, r.metrics)
+	}
+}
+
+// closeQueues stops delivering updates to subscribers.
+func (r *reader) closeQueues() {
+	for _, q := range r.queues {
+		q.close()
+	}
 }
 
-// notifySubscribers sends an update to every subscriber channel in a new go routine.
+// notifySubscribers queues an update for every subscriber channel.
+// Each subscriber receives its updates in order by a single go routine.
+// While a new value is being applied using lockedApply, the update is queued after the struct is unlocked.
 func (r *reader) notifySubscribers(name string, value interface{}) {
 	if len(r.subscribers) == 0 {
 		return
 	}
 
-	r.log(4, "[%s] notifying %d subscribers ...", name, len(r.subscribers))
-
 	update := Update{
 		Name:  name,
 		Value: value,
 	}
 
-	for i, sub := range r.subscribers {
-		go func(id int, ch chan Update) {
-			r.log(4, "[%s] notifying subscriber %d ...", name, id)
-			ch <- update
-			r.log(4, "[%s] subscriber %d notified", name, id)
-		}(i, sub)
+	if r.deferring {
+		r.deferred = append(r.deferred, update)
+		return
 	}
+
+	r.queueUpdate(update)
 }
 
-func (r *reader) iterateOnFields(vStruct reflect.Value, handle func(v reflect.Value, fieldName, flagName, envName, fileEnvName, listSep string)) {
-	// Iterate over struct fields
-	for i := 0; i < vStruct.NumField(); i++ {
-		v := vStruct.Field(i)        // reflect.Value       --> vField.Kind(), vField.Type().Name(), vField.Type().Kind(), vField.Interface()
-		t := v.Type()                // reflect.Type        --> t.Kind(), t.PkgPath(), t.Name(), t.NumField()
-		f := vStruct.Type().Field(i) // reflect.StructField --> f.Name, f.Type.Name(), f.Type.Kind(), f.Tag.Get(tag)
+// queueUpdate pushes an update to the queue of every subscriber.
+func (r *reader) queueUpdate(update Update) {
+	if r.queues == nil {
+		r.startQueues()
+	}
 
-		// Skip unexported and unsupported fields
-		if !v.CanSet() || !isTypeSupported(t) {
-			continue
-		}
+	name := update.Name
+	r.log(4, "notifying subscribers", "field", name, "subscribers", len(r.subscribers))
 
-		// `flag:"..."`
-		flagName := f.Tag.Get(tagFlag)
-		if flagName == "" {
-			flagName = r.prefixFlag + getFlagName(f.Name)
+	for _, q := range r.queues {
+		if q.push(update) {
+			r.log(4, "update queued", "field", name, "subscriber", q.id)
+		} else {
+			r.log(4, "update dropped", "field", name, "subscriber", q.id)
 		}
+	}
+}
 
-		// `env:"..."`
-		envName := f.Tag.Get(tagEnv)
-		if envName == "" {
-			envName = r.prefixEnv + getEnvVarName(f.Name)
-		}
+// iterateOnFields calls handle for every exported and supported field of a struct using the plan for its type.
+func (r *reader) iterateOnFields(vStruct reflect.Value, handle func(v reflect.Value, p *fieldPlan)) {
+	plan := r.plan(vStruct.Type())
+	for i := range plan.fields {
+		p := &plan.fields[i]
+		handle(vStruct.Field(p.index), p)
+	}
+}
+
+func (r *reader) registerFlags(vStruct reflect.Value) {
+	r.log(2, "registering configuration flags")
+	r.logLine(2)
 
-		// `fileenv:"..."`
-		fileEnvName := f.Tag.Get(tagFileEnv)
-		if fileEnvName == "" {
-			fileEnvName = r.prefixFileEnv + getFileEnvVarName(f.Name)
+	r.iterateOnFields(vStruct, func(v reflect.Value, p *fieldPlan) {
+		if p.flagName == skip {
+			return
 		}
 
-		// `sep:"..."`
-		listSep := f.Tag.Get(tagSep)
-		if listSep == "" {
-			listSep = r.listSep
+		r.defineFlag(p, v.Interface())
+
+		if p.structList {
+			r.defineElemFlags(p, v.Type().Elem())
 		}
+	})
 
-		handle(v, f.Name, flagName, envName, fileEnvName, listSep)
-	}
+	r.logLine(5)
 }
 
-func (r *reader) registerFlags(vStruct reflect.Value) {
-	r.log(2, "Registering configuration flags ...")
-	r.log(2, line)
+// defineFlag defines a flag for a field and its aliases, so flag.Parse() can be called.
+func (r *reader) defineFlag(p *fieldPlan, defaultValue interface{}) {
+	for _, alias := range p.aliases.flag {
+		if flag.Lookup(alias) != nil {
+			continue
+		}
 
-	r.iterateOnFields(vStruct, func(v reflect.Value, fieldName, flagName, envName, fileEnvName, listSep string) {
-		if flagName == skip {
-			return
+		usage := "alias for -" + p.flagName
+		if p.aliases.isDeprecated(alias) {
+			usage = "deprecated alias for -" + p.flagName
 		}
 
-		var dataType string
-		if v.Kind() == reflect.Slice {
-			dataType = fmt.Sprintf("[]%s", reflect.TypeOf(v.Interface()).Elem())
+		if p.isBool {
+			flag.Bool(alias, fmt.Sprintf("%v", defaultValue) == "true", usage)
 		} else {
-			dataType = v.Type().String()
-		}
-
-		defaultValue := fmt.Sprintf("%v", v.Interface())
-
-		usage := fmt.Sprintf(
-			"%s:\t\t\t\t%s\n%s:\t\t\t\t%s\n%s:\t\t\t%s\n%s:\t%s",
-			"data type", dataType,
-			"default value", defaultValue,
-			"environment variable", envName,
-			"environment variable for file path", fileEnvName,
-		)
-
-		// Define a flag for the field, so flag.Parse() can be called
-		if flag.Lookup(flagName) == nil {
-			switch v.Kind() {
-			case reflect.Bool:
-				flag.Bool(flagName, v.Bool(), usage)
-			default:
-				flag.Var(&flagValue{}, flagName, usage)
-			}
+			flag.Var(&flagValue{}, alias, usage)
 		}
 
-		r.log(5, "[%s] flag registered: %s", fieldName, flagName)
-	})
+		r.log(5, "flag registered", "field", p.name, "flag", alias)
+	}
 
-	r.log(5, line)
+	// A flag is defined only once, so there is no need to make the usage again for repeated loads
+	if flag.Lookup(p.flagName) != nil {
+		return
+	}
+
+	defaultStr := fmt.Sprintf("%v", defaultValue)
+
+	usage := fmt.Sprintf(
+		"%s:\t\t\t\t%s\n%s:\t\t\t\t%s\n%s:\t\t\t%s\n%s:\t%s",
+		"data type", p.dataType,
+		"default value", defaultStr,
+		"environment variable", p.envName,
+		"environment variable for file path", p.fileEnvName,
+	)
+
+	if p.isBool {
+		flag.Bool(p.flagName, defaultStr == "true", usage)
+	} else {
+		flag.Var(&flagValue{}, p.flagName, usage)
+	}
+
+	r.log(5, "flag registered", "field", p.name, "flag", p.flagName)
 }
 
-func (r *reader) readFields(vStruct reflect.Value) {
-	r.log(2, "Reading configuration values ...")
-	r.log(2, line)
+// readFields reads values for fields of a struct and sets them.
+// If expanding values is enabled, an error is returned for the first value that cannot be expanded.
+func (r *reader) readFields(vStruct reflect.Value) error {
+	r.log(2, "reading configuration values")
+	r.logLine(2)
 
-	r.iterateOnFields(vStruct, func(v reflect.Value, fieldName, flagName, envName, fileEnvName, listSep string) {
-		r.log(5, "[%s] expecting flag name: %s", fieldName, flagName)
-		r.log(5, "[%s] expecting environment variable name: %s", fieldName, envName)
-		r.log(5, "[%s] expecting file environment variable name: %s", fieldName, fileEnvName)
-		r.log(5, "[%s] expecting list separator: %s", fieldName, listSep)
-		defer r.log(5, line)
+	// Command-line arguments are parsed only once for all fields
+	r.args = parseFlagArgs(os.Args)
+
+	type pending struct {
+		f      fieldInfo
+		val    string
+		source Source
+	}
+
+	// Values that cannot be set are reported all together
+	invalid := []string{}
+
+	// First, read the values of all fields, so fields can refer to each other when expanding values
+	fields := []pending{}
+	r.iterateOnFields(vStruct, func(v reflect.Value, p *fieldPlan) {
+		r.log(5, "expecting names", "field", p.name, "flag", p.flagName, "env", p.envName, "fileenv", p.fileEnvName, "sep", p.listSep)
+		defer r.logLine(5)
 
 		// Try reading the configuration value for current field
-		val, path := r.getFieldValue(fieldName, flagName, envName, fileEnvName)
+		val, source, path, name := r.getFieldValue(p)
+
+		// A list of structs is read from indexed names (i.e. UPSTREAMS_0_HOST) if it has no value
+		var elems []elemValue
+		if p.structList && val == "" {
+			elems = r.readElems(p, v.Type().Elem())
+			for _, ev := range elems {
+				if source == SourceDefault && ev.val != "" {
+					source = ev.source
+				}
+				if ev.source == SourceFile {
+					r.markSecret(p.name)
+				}
+			}
+		}
 
-		// If no value, skip this field
-		if val == "" {
-			r.log(5, "[%s] falling back to default value: %v", fieldName, v.Interface())
-			return
+		// Keep the track of where the value for each field is read from
+		r.fieldsMu.Lock()
+		field := Field{
+			Name:    p.name,
+			Type:    p.typ,
+			Flag:    describeName(p.flagName),
+			Env:     describeName(p.envName),
+			FileEnv: describeName(p.fileEnvName),
+			Source:  source,
+			Path:    path,
+		}
+		if p.aliases.isDeprecated(name) {
+			field.Deprecated = name
 		}
+		r.fields = append(r.fields, field)
+		r.fieldsMu.Unlock()
 
 		f := fieldInfo{
-			value:   v,
-			name:    fieldName,
-			listSep: listSep,
+			value:    v,
+			name:     p.name,
+			listSep:  p.listSep,
+			encoding: p.encoding,
+			layout:   p.layout,
+			unit:     p.unit,
+			format:   p.format,
+			binary:   p.binary,
+			from:     describeSource(source, name, path),
+		}
+
+		if p.structList {
+			f.list = p
 		}
 
 		// Keep the track of which fields are read from which files
+		// A file may not exist yet, so it can be watched for being created later.
 		if path != "" {
-			r.filesToFields[path] = f
+			ff := f
+			ff.from = describeSource(SourceFile, name, path)
+			r.filesToFields[path] = ff
+		}
+
+		// The whole list is read again when the file for any of its elements changes
+		for _, ev := range elems {
+			if ev.path != "" {
+				ff := f
+				ff.indexed = true
+				r.filesToFields[ev.path] = ff
+			}
+		}
+
+		// The elements are only used if any of them has a value, so the default value is kept otherwise
+		if hasElemValues(elems) {
+			f.indexed = true
+			f.elems = elems
 		}
 
-		r.setFieldValue(f, val)
+		// Binary values are never expanded
+		if r.expand && !p.binary {
+			r.setFieldRawValue(p.name, val, v, p.listSep)
+		}
+
+		fields = append(fields, pending{f, val, source})
 	})
+
+	// Then, set the values of fields
+	var firstErr error
+	for _, p := range fields {
+		f, val := p.f, p.val
+
+		// The values of elements are read, expanded, and set all together
+		// The errors for elements already name the fields and sources of elements.
+		if f.indexed {
+			if _, err := r.setFieldValue(f, val); err != nil {
+				r.log(1, "cannot set value", "field", f.name, "error", err)
+				invalid = append(invalid, err.Error())
+			}
+			continue
+		}
+
+		if r.expand && !f.binary {
+			var err error
+			if val, err = r.expandValue(f.name, val); err != nil {
+				r.log(1, "cannot expand value", "field", f.name, "error", err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+		}
+
+		// If no value, skip this field
+		if val == "" {
+			r.log(5, "falling back to default value", "field", f.name, "source", SourceDefault, "value", f.value.Interface())
+			continue
+		}
+
+		// Keep the track of files referred to by values (i.e. paths to certificate files), so they can be watched too
+		r.trackRefs(f, val)
+
+		// Binary values are read from files as they are and are base64-encoded in flags and environment variables
+		if f.binary && p.source != SourceFile && f.encoding == "" {
+			f.encoding = "base64"
+		}
+
+		if _, err := r.setFieldValue(f, val); err != nil {
+			r.log(1, "cannot set value", "field", f.name, "error", err)
+			invalid = append(invalid, fmt.Sprintf("%s from %s: %s", f.name, f.from, err))
+		}
+	}
+
+	return readError(firstErr, invalid)
+}
+
+// readError returns the error for reading the values of fields.
+// The error for expanding values, if any, is joined with an error listing all values that cannot be set.
+func readError(expandErr error, invalid []string) error {
+	if len(invalid) == 0 {
+		return expandErr
+	}
+
+	invalidErr := fmt.Errorf("invalid values: %s", strings.Join(invalid, "; "))
+	if expandErr == nil {
+		return invalidErr
+	}
+
+	return errors.Join(expandErr, invalidErr)
+}
+
+// describeSource returns where a value is read from for reporting errors (i.e. environment variable LOG_LEVEL).
+// name is the name of the flag or environment variable and path is the path to the file that the value is read from.
+func describeSource(source Source, name, path string) string {
+	switch source {
+	case SourceFlag:
+		return "flag " + name
+	case SourceEnv:
+		return "environment variable " + name
+	case SourceFile:
+		return "file " + path
+	}
+
+	return string(source)
+}
+
+// lockedApply returns an apply function that sets new values while config is locked.
+// Subscribers are notified after config is unlocked, so a subscriber locking config does not block
+// a new value from being applied when its queue is full and the backpressure policy is BackpressureBlock.
+func (r *reader) lockedApply(config sync.Locker) applyFunc {
+	return func(f fieldInfo, val string) error {
+		r.applyMu.Lock()
+		defer r.applyMu.Unlock()
+
+		r.deferring = true
+		config.Lock()
+		_, err := r.setFieldValue(f, val)
+		if err == nil {
+			r.setSource(f.name, SourceFile)
+		}
+		config.Unlock()
+		r.deferring = false
+
+		updates := r.deferred
+		r.deferred = nil
+		for _, u := range updates {
+			r.queueUpdate(u)
+		}
+
+		return err
+	}
+}
+
+// applyValue applies a new value read from a file for a field.
+// If expanding values is enabled, the value is expanded before being applied.
+func (r *reader) applyValue(apply applyFunc, f fieldInfo, val string) error {
+	r.markSecret(f.name)
+
+	if r.expand && !f.binary && !f.indexed {
+		r.setFieldRawValue(f.name, val, f.value, f.listSep)
+
+		var err error
+		if val, err = r.expandValue(f.name, val); err != nil {
+			return err
+		}
+	}
+
+	return apply(f, val)
+}
+
+// trackRefs keeps the track of files referred to by the value of a field, so the field is read again when the files change.
+func (r *reader) trackRefs(f fieldInfo, val string) {
+	if !isTLSType(f.value.Type()) {
+		return
+	}
+
+	for _, path := range tlsPaths(val) {
+		path = filepath.Clean(path)
+		if _, ok := r.filesToFields[path]; !ok {
+			ref := f
+			ref.ref = val
+			r.filesToFields[path] = ref
+			r.log(5, "watching referred file", "field", f.name, "path", path)
+		}
+	}
+}
+
+// watchedValue returns the new value for a field when a watched file changes.
+// If the file is referred to by the value of the field, the same value is read again.
+func (f fieldInfo) watchedValue(content string) string {
+	if f.ref != "" {
+		return f.ref
+	}
+
+	return content
+}
+
+// setSource updates the source that the value of a field is read from.
+func (r *reader) setSource(name string, source Source) {
+	r.fieldsMu.Lock()
+	defer r.fieldsMu.Unlock()
+
+	for i := range r.fields {
+		if r.fields[i].Name == name {
+			r.fields[i].Source = source
+		}
+	}
+}
+
+// describe returns a copy of descriptions of fields.
+func (r *reader) describe() []Field {
+	r.fieldsMu.Lock()
+	defer r.fieldsMu.Unlock()
+
+	fields := make([]Field, len(r.fields))
+	copy(fields, r.fields)
+
+	return fields
 }
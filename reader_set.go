@@ -1,6 +1,9 @@
 package konfig
 
 import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/url"
 	"reflect"
@@ -15,7 +18,7 @@ func (r *reader) setString(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting string value: %s", name, val)
+	r.log(5, "setting string value", "field", name, "value", val)
 	v.SetString(val)
 	r.notifySubscribers(name, val)
 
@@ -32,7 +35,7 @@ func (r *reader) setBool(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting bool value: %t", name, b)
+	r.log(5, "setting bool value", "field", name, "value", b)
 	v.SetBool(b)
 	r.notifySubscribers(name, b)
 
@@ -49,7 +52,7 @@ func (r *reader) setFloat32(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting float32 value: %f", name, f)
+	r.log(5, "setting float32 value", "field", name, "value", f)
 	v.SetFloat(f)
 	r.notifySubscribers(name, float32(f))
 
@@ -66,13 +69,47 @@ func (r *reader) setFloat64(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting float64 value: %f", name, f)
+	r.log(5, "setting float64 value", "field", name, "value", f)
 	v.SetFloat(f)
 	r.notifySubscribers(name, f)
 
 	return true, nil
 }
 
+func (r *reader) setComplex64(v reflect.Value, name, val string) (bool, error) {
+	c, err := strconv.ParseComplex(val, 64)
+	if err != nil {
+		return false, err
+	}
+
+	if v.Complex() == c {
+		return false, nil
+	}
+
+	r.log(5, "setting complex64 value", "field", name, "value", c)
+	v.SetComplex(c)
+	r.notifySubscribers(name, complex64(c))
+
+	return true, nil
+}
+
+func (r *reader) setComplex128(v reflect.Value, name, val string) (bool, error) {
+	c, err := strconv.ParseComplex(val, 128)
+	if err != nil {
+		return false, err
+	}
+
+	if v.Complex() == c {
+		return false, nil
+	}
+
+	r.log(5, "setting complex128 value", "field", name, "value", c)
+	v.SetComplex(c)
+	r.notifySubscribers(name, c)
+
+	return true, nil
+}
+
 func (r *reader) setInt(v reflect.Value, name, val string) (bool, error) {
 	// int size and range are platform-dependent
 	i, err := strconv.ParseInt(val, 10, 64)
@@ -84,7 +121,7 @@ func (r *reader) setInt(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int value: %d", name, i)
+	r.log(5, "setting int value", "field", name, "value", i)
 	v.SetInt(i)
 	r.notifySubscribers(name, int(i))
 
@@ -101,7 +138,7 @@ func (r *reader) setInt8(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int8 value: %d", name, i)
+	r.log(5, "setting int8 value", "field", name, "value", i)
 	v.SetInt(i)
 	r.notifySubscribers(name, int8(i))
 
@@ -118,7 +155,7 @@ func (r *reader) setInt16(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int16 value: %d", name, i)
+	r.log(5, "setting int16 value", "field", name, "value", i)
 	v.SetInt(i)
 	r.notifySubscribers(name, int16(i))
 
@@ -135,7 +172,7 @@ func (r *reader) setInt32(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int32 value: %d", name, i)
+	r.log(5, "setting int32 value", "field", name, "value", i)
 	v.SetInt(i)
 	r.notifySubscribers(name, int32(i))
 
@@ -153,7 +190,7 @@ func (r *reader) setInt64(v reflect.Value, name, val string) (bool, error) {
 			return false, nil
 		}
 
-		r.log(5, "[%s] setting duration value: %s", name, d)
+		r.log(5, "setting duration value", "field", name, "value", d)
 		v.Set(reflect.ValueOf(d))
 		r.notifySubscribers(name, d)
 
@@ -169,7 +206,7 @@ func (r *reader) setInt64(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int64 value: %d", name, i)
+	r.log(5, "setting int64 value", "field", name, "value", i)
 	v.SetInt(i)
 	r.notifySubscribers(name, i)
 
@@ -187,7 +224,7 @@ func (r *reader) setUint(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint value: %d", name, u)
+	r.log(5, "setting uint value", "field", name, "value", u)
 	v.SetUint(u)
 	r.notifySubscribers(name, uint(u))
 
@@ -204,7 +241,7 @@ func (r *reader) setUint8(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint8 value: %d", name, u)
+	r.log(5, "setting uint8 value", "field", name, "value", u)
 	v.SetUint(u)
 	r.notifySubscribers(name, uint8(u))
 
@@ -221,7 +258,7 @@ func (r *reader) setUint16(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint16 value: %d", name, u)
+	r.log(5, "setting uint16 value", "field", name, "value", u)
 	v.SetUint(u)
 	r.notifySubscribers(name, uint16(u))
 
@@ -238,7 +275,7 @@ func (r *reader) setUint32(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint32 value: %d", name, u)
+	r.log(5, "setting uint32 value", "field", name, "value", u)
 	v.SetUint(u)
 	r.notifySubscribers(name, uint32(u))
 
@@ -255,7 +292,7 @@ func (r *reader) setUint64(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting unsigned integer value: %d", name, u)
+	r.log(5, "setting unsigned integer value", "field", name, "value", u)
 	v.SetUint(u)
 	r.notifySubscribers(name, u)
 
@@ -277,7 +314,7 @@ func (r *reader) setStruct(v reflect.Value, name, val string) (bool, error) {
 		}
 
 		// u is a pointer
-		r.log(5, "[%s] setting url value: %s", name, val)
+		r.log(5, "setting url value", "field", name, "value", val)
 		v.Set(reflect.ValueOf(u).Elem())
 		r.notifySubscribers(name, *u)
 
@@ -294,22 +331,71 @@ func (r *reader) setStruct(v reflect.Value, name, val string) (bool, error) {
 		}
 
 		// r is a pointer
-		r.log(5, "[%s] setting regexp value: %s", name, val)
+		r.log(5, "setting regexp value", "field", name, "value", val)
 		v.Set(reflect.ValueOf(re).Elem())
 		r.notifySubscribers(name, *re)
 
+		return true, nil
+	} else if t.PkgPath() == "crypto/tls" && t.Name() == "Certificate" {
+		cert, err := ParseCertificate(val)
+		if err != nil {
+			return false, err
+		}
+
+		if certificatesEqual(v.Interface().(tls.Certificate), *cert) {
+			return false, nil
+		}
+
+		// Certificates and keys are not logged
+		r.log(5, "setting certificate value", "field", name)
+		v.Set(reflect.ValueOf(cert).Elem())
+		r.notifySubscribers(name, *cert)
+
+		return true, nil
+	} else if t.PkgPath() == "crypto/x509" && t.Name() == "CertPool" {
+		pool, err := ParseCertPool(val)
+		if err != nil {
+			return false, err
+		}
+
+		old := v.Addr().Interface().(*x509.CertPool)
+		if old.Equal(pool) {
+			return false, nil
+		}
+
+		r.log(5, "setting cert pool value", "field", name)
+		v.Set(reflect.ValueOf(pool).Elem())
+		r.notifySubscribers(name, *pool)
+
 		return true, nil
 	}
 
 	return false, fmt.Errorf("unsupported type: %s.%s", t.PkgPath(), t.Name())
 }
 
+func (r *reader) setText(v reflect.Value, name, val, layout string, tt textType) (bool, error) {
+	i, err := tt.parse(val, layout)
+	if err != nil {
+		return false, err
+	}
+
+	if reflect.DeepEqual(v.Interface(), i) {
+		return false, nil
+	}
+
+	r.log(5, fmt.Sprintf("setting %s value", tt.name), "field", name, "value", i)
+	v.Set(reflect.ValueOf(i))
+	r.notifySubscribers(name, i)
+
+	return true, nil
+}
+
 func (r *reader) setStringPtr(v reflect.Value, name, val string) (bool, error) {
 	if !v.IsZero() && v.Elem().String() == val {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting string pointer: %s", name, val)
+	r.log(5, "setting string pointer", "field", name, "value", val)
 	v.Set(reflect.ValueOf(&val))
 	r.notifySubscribers(name, &val)
 
@@ -326,7 +412,7 @@ func (r *reader) setBoolPtr(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting bool pointer: %t", name, b)
+	r.log(5, "setting bool pointer", "field", name, "value", b)
 	v.Set(reflect.ValueOf(&b))
 	r.notifySubscribers(name, &b)
 
@@ -344,7 +430,7 @@ func (r *reader) setFloat32Ptr(v reflect.Value, name, val string) (bool, error)
 	}
 
 	f32 := float32(f64)
-	r.log(5, "[%s] setting float32 pointer: %f", name, f32)
+	r.log(5, "setting float32 pointer", "field", name, "value", f32)
 	v.Set(reflect.ValueOf(&f32))
 	r.notifySubscribers(name, &f32)
 
@@ -361,13 +447,48 @@ func (r *reader) setFloat64Ptr(v reflect.Value, name, val string) (bool, error)
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting float64 pointer: %f", name, f64)
+	r.log(5, "setting float64 pointer", "field", name, "value", f64)
 	v.Set(reflect.ValueOf(&f64))
 	r.notifySubscribers(name, &f64)
 
 	return true, nil
 }
 
+func (r *reader) setComplex64Ptr(v reflect.Value, name, val string) (bool, error) {
+	c128, err := strconv.ParseComplex(val, 64)
+	if err != nil {
+		return false, err
+	}
+
+	if !v.IsZero() && v.Elem().Complex() == c128 {
+		return false, nil
+	}
+
+	c64 := complex64(c128)
+	r.log(5, "setting complex64 pointer", "field", name, "value", c64)
+	v.Set(reflect.ValueOf(&c64))
+	r.notifySubscribers(name, &c64)
+
+	return true, nil
+}
+
+func (r *reader) setComplex128Ptr(v reflect.Value, name, val string) (bool, error) {
+	c128, err := strconv.ParseComplex(val, 128)
+	if err != nil {
+		return false, err
+	}
+
+	if !v.IsZero() && v.Elem().Complex() == c128 {
+		return false, nil
+	}
+
+	r.log(5, "setting complex128 pointer", "field", name, "value", c128)
+	v.Set(reflect.ValueOf(&c128))
+	r.notifySubscribers(name, &c128)
+
+	return true, nil
+}
+
 func (r *reader) setIntPtr(v reflect.Value, name, val string) (bool, error) {
 	// int size and range are platform-dependent
 	i64, err := strconv.ParseInt(val, 10, 64)
@@ -380,7 +501,7 @@ func (r *reader) setIntPtr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	i := int(i64)
-	r.log(5, "[%s] setting int pointer: %d", name, i)
+	r.log(5, "setting int pointer", "field", name, "value", i)
 	v.Set(reflect.ValueOf(&i))
 	r.notifySubscribers(name, &i)
 
@@ -398,7 +519,7 @@ func (r *reader) setInt8Ptr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	i8 := int8(i64)
-	r.log(5, "[%s] setting int8 pointer: %d", name, i8)
+	r.log(5, "setting int8 pointer", "field", name, "value", i8)
 	v.Set(reflect.ValueOf(&i8))
 	r.notifySubscribers(name, &i8)
 
@@ -416,7 +537,7 @@ func (r *reader) setInt16Ptr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	i16 := int16(i64)
-	r.log(5, "[%s] setting int16 pointer: %d", name, i16)
+	r.log(5, "setting int16 pointer", "field", name, "value", i16)
 	v.Set(reflect.ValueOf(&i16))
 	r.notifySubscribers(name, &i16)
 
@@ -434,7 +555,7 @@ func (r *reader) setInt32Ptr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	i32 := int32(i64)
-	r.log(5, "[%s] setting int32 pointer: %d", name, i32)
+	r.log(5, "setting int32 pointer", "field", name, "value", i32)
 	v.Set(reflect.ValueOf(&i32))
 	r.notifySubscribers(name, &i32)
 
@@ -454,7 +575,7 @@ func (r *reader) setInt64Ptr(v reflect.Value, name, val string) (bool, error) {
 			return false, nil
 		}
 
-		r.log(5, "[%s] setting duration pointer: %s", name, d)
+		r.log(5, "setting duration pointer", "field", name, "value", d)
 		v.Set(reflect.ValueOf(&d))
 		r.notifySubscribers(name, &d)
 
@@ -470,7 +591,7 @@ func (r *reader) setInt64Ptr(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int64 pointer: %d", name, i64)
+	r.log(5, "setting int64 pointer", "field", name, "value", i64)
 	v.Set(reflect.ValueOf(&i64))
 	r.notifySubscribers(name, &i64)
 
@@ -489,7 +610,7 @@ func (r *reader) setUintPtr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	u := uint(u64)
-	r.log(5, "[%s] setting uint pointer: %d", name, u)
+	r.log(5, "setting uint pointer", "field", name, "value", u)
 	v.Set(reflect.ValueOf(&u))
 	r.notifySubscribers(name, &u)
 
@@ -507,7 +628,7 @@ func (r *reader) setUint8Ptr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	u8 := uint8(u64)
-	r.log(5, "[%s] setting uint8 pointer: %d", name, u8)
+	r.log(5, "setting uint8 pointer", "field", name, "value", u8)
 	v.Set(reflect.ValueOf(&u8))
 	r.notifySubscribers(name, &u8)
 
@@ -525,7 +646,7 @@ func (r *reader) setUint16Ptr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	u16 := uint16(u64)
-	r.log(5, "[%s] setting uint16 pointer: %d", name, u16)
+	r.log(5, "setting uint16 pointer", "field", name, "value", u16)
 	v.Set(reflect.ValueOf(&u16))
 	r.notifySubscribers(name, &u16)
 
@@ -543,7 +664,7 @@ func (r *reader) setUint32Ptr(v reflect.Value, name, val string) (bool, error) {
 	}
 
 	u32 := uint32(u64)
-	r.log(5, "[%s] setting uint32 pointer: %d", name, u32)
+	r.log(5, "setting uint32 pointer", "field", name, "value", u32)
 	v.Set(reflect.ValueOf(&u32))
 	r.notifySubscribers(name, &u32)
 
@@ -560,7 +681,7 @@ func (r *reader) setUint64Ptr(v reflect.Value, name, val string) (bool, error) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint pointer: %d", name, u64)
+	r.log(5, "setting uint pointer", "field", name, "value", u64)
 	v.Set(reflect.ValueOf(&u64))
 	r.notifySubscribers(name, &u64)
 
@@ -581,7 +702,7 @@ func (r *reader) setStructPtr(v reflect.Value, name, val string) (bool, error) {
 		}
 
 		// u is a pointer
-		r.log(5, "[%s] setting url pointer: %s", name, val)
+		r.log(5, "setting url pointer", "field", name, "value", val)
 		v.Set(reflect.ValueOf(u))
 		r.notifySubscribers(name, u)
 
@@ -597,22 +718,73 @@ func (r *reader) setStructPtr(v reflect.Value, name, val string) (bool, error) {
 		}
 
 		// r is a pointer
-		r.log(5, "[%s] setting regexp pointer: %s", name, val)
+		r.log(5, "setting regexp pointer", "field", name, "value", val)
 		v.Set(reflect.ValueOf(re))
 		r.notifySubscribers(name, re)
 
+		return true, nil
+	} else if t.PkgPath() == "crypto/tls" && t.Name() == "Certificate" {
+		cert, err := ParseCertificate(val)
+		if err != nil {
+			return false, err
+		}
+
+		if !v.IsZero() && certificatesEqual(*v.Interface().(*tls.Certificate), *cert) {
+			return false, nil
+		}
+
+		// Certificates and keys are not logged
+		r.log(5, "setting certificate pointer", "field", name)
+		v.Set(reflect.ValueOf(cert))
+		r.notifySubscribers(name, cert)
+
+		return true, nil
+	} else if t.PkgPath() == "crypto/x509" && t.Name() == "CertPool" {
+		pool, err := ParseCertPool(val)
+		if err != nil {
+			return false, err
+		}
+
+		if !v.IsZero() && v.Interface().(*x509.CertPool).Equal(pool) {
+			return false, nil
+		}
+
+		r.log(5, "setting cert pool pointer", "field", name)
+		v.Set(reflect.ValueOf(pool))
+		r.notifySubscribers(name, pool)
+
 		return true, nil
 	}
 
 	return false, fmt.Errorf("unsupported type: %s.%s", t.PkgPath(), t.Name())
 }
 
+func (r *reader) setTextPtr(v reflect.Value, name, val, layout string, tt textType) (bool, error) {
+	i, err := tt.parse(val, layout)
+	if err != nil {
+		return false, err
+	}
+
+	if !v.IsZero() && reflect.DeepEqual(v.Elem().Interface(), i) {
+		return false, nil
+	}
+
+	p := reflect.New(v.Type().Elem())
+	p.Elem().Set(reflect.ValueOf(i))
+
+	r.log(5, fmt.Sprintf("setting %s pointer", tt.name), "field", name, "value", i)
+	v.Set(p)
+	r.notifySubscribers(name, p.Interface())
+
+	return true, nil
+}
+
 func (r *reader) setStringSlice(v reflect.Value, name string, vals []string) (bool, error) {
 	if reflect.DeepEqual(v.Interface(), vals) {
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting string slice: %v", name, vals)
+	r.log(5, "setting string slice", "field", name, "value", vals)
 	v.Set(reflect.ValueOf(vals))
 	r.notifySubscribers(name, vals)
 
@@ -634,7 +806,7 @@ func (r *reader) setBoolSlice(v reflect.Value, name string, vals []string) (bool
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting bool slice: %v", name, bools)
+	r.log(5, "setting bool slice", "field", name, "value", bools)
 	v.Set(reflect.ValueOf(bools))
 	r.notifySubscribers(name, bools)
 
@@ -656,7 +828,7 @@ func (r *reader) setFloat32Slice(v reflect.Value, name string, vals []string) (b
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting float32 slice: %v", name, floats)
+	r.log(5, "setting float32 slice", "field", name, "value", floats)
 	v.Set(reflect.ValueOf(floats))
 	r.notifySubscribers(name, floats)
 
@@ -678,13 +850,57 @@ func (r *reader) setFloat64Slice(v reflect.Value, name string, vals []string) (b
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting float64 slice: %v", name, floats)
+	r.log(5, "setting float64 slice", "field", name, "value", floats)
 	v.Set(reflect.ValueOf(floats))
 	r.notifySubscribers(name, floats)
 
 	return true, nil
 }
 
+func (r *reader) setComplex64Slice(v reflect.Value, name string, vals []string) (bool, error) {
+	complexes := []complex64{}
+	for _, val := range vals {
+		c, err := strconv.ParseComplex(val, 64)
+		if err != nil {
+			return false, err
+		}
+
+		complexes = append(complexes, complex64(c))
+	}
+
+	if reflect.DeepEqual(v.Interface(), complexes) {
+		return false, nil
+	}
+
+	r.log(5, "setting complex64 slice", "field", name, "value", complexes)
+	v.Set(reflect.ValueOf(complexes))
+	r.notifySubscribers(name, complexes)
+
+	return true, nil
+}
+
+func (r *reader) setComplex128Slice(v reflect.Value, name string, vals []string) (bool, error) {
+	complexes := []complex128{}
+	for _, val := range vals {
+		c, err := strconv.ParseComplex(val, 128)
+		if err != nil {
+			return false, err
+		}
+
+		complexes = append(complexes, c)
+	}
+
+	if reflect.DeepEqual(v.Interface(), complexes) {
+		return false, nil
+	}
+
+	r.log(5, "setting complex128 slice", "field", name, "value", complexes)
+	v.Set(reflect.ValueOf(complexes))
+	r.notifySubscribers(name, complexes)
+
+	return true, nil
+}
+
 func (r *reader) setIntSlice(v reflect.Value, name string, vals []string) (bool, error) {
 	// int size and range are platform-dependent
 	ints := []int{}
@@ -701,7 +917,7 @@ func (r *reader) setIntSlice(v reflect.Value, name string, vals []string) (bool,
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int slice: %v", name, ints)
+	r.log(5, "setting int slice", "field", name, "value", ints)
 	v.Set(reflect.ValueOf(ints))
 	r.notifySubscribers(name, ints)
 
@@ -723,7 +939,7 @@ func (r *reader) setInt8Slice(v reflect.Value, name string, vals []string) (bool
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int8 slice: %v", name, ints)
+	r.log(5, "setting int8 slice", "field", name, "value", ints)
 	v.Set(reflect.ValueOf(ints))
 	r.notifySubscribers(name, ints)
 
@@ -745,7 +961,7 @@ func (r *reader) setInt16Slice(v reflect.Value, name string, vals []string) (boo
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int16 slice: %v", name, ints)
+	r.log(5, "setting int16 slice", "field", name, "value", ints)
 	v.Set(reflect.ValueOf(ints))
 	r.notifySubscribers(name, ints)
 
@@ -767,7 +983,7 @@ func (r *reader) setInt32Slice(v reflect.Value, name string, vals []string) (boo
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int32 slice: %v", name, ints)
+	r.log(5, "setting int32 slice", "field", name, "value", ints)
 	v.Set(reflect.ValueOf(ints))
 	r.notifySubscribers(name, ints)
 
@@ -792,7 +1008,7 @@ func (r *reader) setInt64Slice(v reflect.Value, name string, vals []string) (boo
 			return false, nil
 		}
 
-		r.log(5, "[%s] setting duration slice: %v", name, durations)
+		r.log(5, "setting duration slice", "field", name, "value", durations)
 		v.Set(reflect.ValueOf(durations))
 		r.notifySubscribers(name, durations)
 
@@ -813,7 +1029,7 @@ func (r *reader) setInt64Slice(v reflect.Value, name string, vals []string) (boo
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting int64 slice: %v", name, ints)
+	r.log(5, "setting int64 slice", "field", name, "value", ints)
 	v.Set(reflect.ValueOf(ints))
 	r.notifySubscribers(name, ints)
 
@@ -836,7 +1052,7 @@ func (r *reader) setUintSlice(v reflect.Value, name string, vals []string) (bool
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint slice: %v", name, uints)
+	r.log(5, "setting uint slice", "field", name, "value", uints)
 	v.Set(reflect.ValueOf(uints))
 	r.notifySubscribers(name, uints)
 
@@ -858,13 +1074,27 @@ func (r *reader) setUint8Slice(v reflect.Value, name string, vals []string) (boo
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint8 slice: %v", name, uints)
+	r.log(5, "setting uint8 slice", "field", name, "value", uints)
 	v.Set(reflect.ValueOf(uints))
 	r.notifySubscribers(name, uints)
 
 	return true, nil
 }
 
+func (r *reader) setBytes(v reflect.Value, name, val string) (bool, error) {
+	b := []byte(val)
+
+	if bytes.Equal(v.Bytes(), b) {
+		return false, nil
+	}
+
+	r.log(5, "setting bytes value", "field", name, "bytes", len(b))
+	v.SetBytes(b)
+	r.notifySubscribers(name, b)
+
+	return true, nil
+}
+
 func (r *reader) setUint16Slice(v reflect.Value, name string, vals []string) (bool, error) {
 	uints := []uint16{}
 	for _, val := range vals {
@@ -880,7 +1110,7 @@ func (r *reader) setUint16Slice(v reflect.Value, name string, vals []string) (bo
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint16 slice: %v", name, uints)
+	r.log(5, "setting uint16 slice", "field", name, "value", uints)
 	v.Set(reflect.ValueOf(uints))
 	r.notifySubscribers(name, uints)
 
@@ -902,7 +1132,7 @@ func (r *reader) setUint32Slice(v reflect.Value, name string, vals []string) (bo
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint32 slice: %v", name, uints)
+	r.log(5, "setting uint32 slice", "field", name, "value", uints)
 	v.Set(reflect.ValueOf(uints))
 	r.notifySubscribers(name, uints)
 
@@ -924,13 +1154,35 @@ func (r *reader) setUint64Slice(v reflect.Value, name string, vals []string) (bo
 		return false, nil
 	}
 
-	r.log(5, "[%s] setting uint64 slice: %v", name, uints)
+	r.log(5, "setting uint64 slice", "field", name, "value", uints)
 	v.Set(reflect.ValueOf(uints))
 	r.notifySubscribers(name, uints)
 
 	return true, nil
 }
 
+func (r *reader) setTextSlice(v reflect.Value, name string, vals []string, layout string, tt textType) (bool, error) {
+	s := reflect.MakeSlice(v.Type(), 0, len(vals))
+	for _, val := range vals {
+		i, err := tt.parse(val, layout)
+		if err != nil {
+			return false, err
+		}
+
+		s = reflect.Append(s, reflect.ValueOf(i))
+	}
+
+	if reflect.DeepEqual(v.Interface(), s.Interface()) {
+		return false, nil
+	}
+
+	r.log(5, fmt.Sprintf("setting %s slice", tt.name), "field", name, "value", s.Interface())
+	v.Set(s)
+	r.notifySubscribers(name, s.Interface())
+
+	return true, nil
+}
+
 func (r *reader) setStructSlice(v reflect.Value, name string, vals []string) (bool, error) {
 	t := reflect.TypeOf(v.Interface()).Elem()
 
@@ -950,7 +1202,7 @@ func (r *reader) setStructSlice(v reflect.Value, name string, vals []string) (bo
 			return false, nil
 		}
 
-		r.log(5, "[%s] setting url slice: %v", name, urls)
+		r.log(5, "setting url slice", "field", name, "value", urls)
 		v.Set(reflect.ValueOf(urls))
 		r.notifySubscribers(name, urls)
 
@@ -971,7 +1223,7 @@ func (r *reader) setStructSlice(v reflect.Value, name string, vals []string) (bo
 			return false, nil
 		}
 
-		r.log(5, "[%s] setting regexp slice: %v", name, regexps)
+		r.log(5, "setting regexp slice", "field", name, "value", regexps)
 		v.Set(reflect.ValueOf(regexps))
 		r.notifySubscribers(name, regexps)
 
@@ -981,7 +1233,82 @@ func (r *reader) setStructSlice(v reflect.Value, name string, vals []string) (bo
 	return false, fmt.Errorf("unsupported type: %s.%s", t.PkgPath(), t.Name())
 }
 
+// setArray sets a new value for a fixed-size array.
+// The number of values should be the same as the length of the array.
+func (r *reader) setArray(f fieldInfo, vals []string) (bool, error) {
+	t := f.value.Type()
+	if len(vals) != t.Len() {
+		return false, fmt.Errorf("invalid number of values: expected %d, got %d", t.Len(), len(vals))
+	}
+
+	// The values are read into a slice first, so all slice types are supported for arrays too
+	s := reflect.New(reflect.SliceOf(t.Elem())).Elem()
+	sf := fieldInfo{
+		value:   s,
+		name:    f.name,
+		listSep: f.listSep,
+		layout:  f.layout,
+	}
+
+	if _, err := r.elemReader().setFieldValue(sf, strings.Join(vals, f.listSep)); err != nil {
+		return false, err
+	}
+
+	a := reflect.New(t).Elem()
+	reflect.Copy(a, s)
+
+	if reflect.DeepEqual(f.value.Interface(), a.Interface()) {
+		return false, nil
+	}
+
+	r.log(5, "setting array", "field", f.name, "value", a.Interface())
+	f.value.Set(a)
+	r.notifySubscribers(f.name, a.Interface())
+
+	return true, nil
+}
+
 func (r *reader) setFieldValue(f fieldInfo, val string) (bool, error) {
+	// `encoding:"..."`
+	if f.encoding != "" {
+		var err error
+		if val, err = decodeValue(f.encoding, val); err != nil {
+			r.log(1, "cannot decode value", "field", f.name, "encoding", f.encoding, "error", err)
+			return false, err
+		}
+	}
+
+	// `format:"..."`
+	if f.format != "" {
+		return r.setFormatted(f, val)
+	}
+
+	// Lists of structs are read as a whole
+	if f.list != nil {
+		return r.setStructList(f, val)
+	}
+
+	// `unit:"..."`
+	if f.unit != "" {
+		var err error
+		if val, err = convertUnit(f.unit, val, f.listSep); err != nil {
+			r.log(1, "cannot convert value", "field", f.name, "unit", f.unit, "error", err)
+			return false, err
+		}
+	}
+
+	// Types parsed from strings (i.e. net.IP) are checked first, since their kinds overlap with other types
+	if tt, kind, ok := lookupTextType(f.value.Type()); ok {
+		switch kind {
+		case reflect.Ptr:
+			return r.setTextPtr(f.value, f.name, val, f.layout, tt)
+		case reflect.Slice:
+			return r.setTextSlice(f.value, f.name, strings.Split(val, f.listSep), f.layout, tt)
+		default:
+			return r.setText(f.value, f.name, val, f.layout, tt)
+		}
+	}
+
 	switch f.value.Kind() {
 	case reflect.String:
 		return r.setString(f.value, f.name, val)
@@ -991,6 +1318,10 @@ func (r *reader) setFieldValue(f fieldInfo, val string) (bool, error) {
 		return r.setFloat32(f.value, f.name, val)
 	case reflect.Float64:
 		return r.setFloat64(f.value, f.name, val)
+	case reflect.Complex64:
+		return r.setComplex64(f.value, f.name, val)
+	case reflect.Complex128:
+		return r.setComplex128(f.value, f.name, val)
 	case reflect.Int:
 		return r.setInt(f.value, f.name, val)
 	case reflect.Int8:
@@ -1026,6 +1357,10 @@ func (r *reader) setFieldValue(f fieldInfo, val string) (bool, error) {
 			return r.setFloat32Ptr(f.value, f.name, val)
 		case reflect.Float64:
 			return r.setFloat64Ptr(f.value, f.name, val)
+		case reflect.Complex64:
+			return r.setComplex64Ptr(f.value, f.name, val)
+		case reflect.Complex128:
+			return r.setComplex128Ptr(f.value, f.name, val)
 		case reflect.Int:
 			return r.setIntPtr(f.value, f.name, val)
 		case reflect.Int8:
@@ -1051,6 +1386,10 @@ func (r *reader) setFieldValue(f fieldInfo, val string) (bool, error) {
 		}
 
 	case reflect.Slice:
+		if f.binary {
+			return r.setBytes(f.value, f.name, val)
+		}
+
 		tSlice := reflect.TypeOf(f.value.Interface()).Elem()
 		vals := strings.Split(val, f.listSep)
 
@@ -1063,6 +1402,10 @@ func (r *reader) setFieldValue(f fieldInfo, val string) (bool, error) {
 			return r.setFloat32Slice(f.value, f.name, vals)
 		case reflect.Float64:
 			return r.setFloat64Slice(f.value, f.name, vals)
+		case reflect.Complex64:
+			return r.setComplex64Slice(f.value, f.name, vals)
+		case reflect.Complex128:
+			return r.setComplex128Slice(f.value, f.name, vals)
 		case reflect.Int:
 			return r.setIntSlice(f.value, f.name, vals)
 		case reflect.Int8:
@@ -1086,6 +1429,9 @@ func (r *reader) setFieldValue(f fieldInfo, val string) (bool, error) {
 		case reflect.Struct:
 			return r.setStructSlice(f.value, f.name, vals)
 		}
+
+	case reflect.Array:
+		return r.setArray(f, strings.Split(val, f.listSep))
 	}
 
 	return false, fmt.Errorf("unsupported kind: %s", f.value.Kind())
@@ -2,6 +2,7 @@ package konfig
 
 import (
 	"net/url"
+	"os"
 	"reflect"
 	"regexp"
 	"testing"
@@ -194,6 +195,106 @@ func TestReaderSetFloat64(t *testing.T) {
 	}
 }
 
+func TestReaderSetComplex64(t *testing.T) {
+	tests := []struct {
+		name            string
+		c               complex64
+		val             string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  complex64
+	}{
+		{
+			"NewValue",
+			1 + 2i, "3-4i",
+			true, "",
+			3 - 4i,
+		},
+		{
+			"NoNewValue",
+			3 - 4i, "(3-4i)",
+			false, "",
+			3 - 4i,
+		},
+		{
+			"InvalidValue",
+			1 + 2i, "invalid",
+			false, `strconv.ParseComplex: parsing "invalid": invalid syntax`,
+			1 + 2i,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			v := reflect.ValueOf(&tc.c).Elem()
+			updated, err := r.setComplex64(v, "Field", tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.c)
+		})
+	}
+}
+
+func TestReaderSetComplex128(t *testing.T) {
+	tests := []struct {
+		name            string
+		c               complex128
+		val             string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  complex128
+	}{
+		{
+			"NewValue",
+			1 + 2i, "2.7182818284+3.14159265359i",
+			true, "",
+			2.7182818284 + 3.14159265359i,
+		},
+		{
+			"NoNewValue",
+			2.7182818284 + 3.14159265359i, "2.7182818284+3.14159265359i",
+			false, "",
+			2.7182818284 + 3.14159265359i,
+		},
+		{
+			"RealOnly",
+			1 + 2i, "5",
+			true, "",
+			5,
+		},
+		{
+			"InvalidValue",
+			1 + 2i, "1+2j",
+			false, `strconv.ParseComplex: parsing "1+2j": invalid syntax`,
+			1 + 2i,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			v := reflect.ValueOf(&tc.c).Elem()
+			updated, err := r.setComplex128(v, "Field", tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.c)
+		})
+	}
+}
+
 func TestReaderSetInt(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1017,6 +1118,116 @@ func TestReaderSetFloat64Ptr(t *testing.T) {
 	}
 }
 
+func TestReaderSetComplex64Ptr(t *testing.T) {
+	c1, c2 := complex64(1+2i), complex64(3-4i)
+
+	tests := []struct {
+		name            string
+		c               *complex64
+		val             string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  *complex64
+	}{
+		{
+			"Nil",
+			nil, "3-4i",
+			true, "",
+			&c2,
+		},
+		{
+			"NewValue",
+			&c1, "3-4i",
+			true, "",
+			&c2,
+		},
+		{
+			"NoNewValue",
+			&c2, "3-4i",
+			false, "",
+			&c2,
+		},
+		{
+			"InvalidValue",
+			&c1, "invalid",
+			false, `strconv.ParseComplex: parsing "invalid": invalid syntax`,
+			&c1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			v := reflect.ValueOf(&tc.c).Elem()
+			updated, err := r.setComplex64Ptr(v, "Field", tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.c)
+		})
+	}
+}
+
+func TestReaderSetComplex128Ptr(t *testing.T) {
+	c1, c2 := complex128(1+2i), complex128(3-4i)
+
+	tests := []struct {
+		name            string
+		c               *complex128
+		val             string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  *complex128
+	}{
+		{
+			"Nil",
+			nil, "3-4i",
+			true, "",
+			&c2,
+		},
+		{
+			"NewValue",
+			&c1, "3-4i",
+			true, "",
+			&c2,
+		},
+		{
+			"NoNewValue",
+			&c2, "3-4i",
+			false, "",
+			&c2,
+		},
+		{
+			"InvalidValue",
+			&c1, "invalid",
+			false, `strconv.ParseComplex: parsing "invalid": invalid syntax`,
+			&c1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			v := reflect.ValueOf(&tc.c).Elem()
+			updated, err := r.setComplex128Ptr(v, "Field", tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.c)
+		})
+	}
+}
+
 func TestReaderSetIntPtr(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1918,6 +2129,112 @@ func TestReaderSetFloat64Slice(t *testing.T) {
 	}
 }
 
+func TestReaderSetComplex64Slice(t *testing.T) {
+	tests := []struct {
+		name            string
+		c               []complex64
+		vals            []string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  []complex64
+	}{
+		{
+			"Nil",
+			nil, []string{"1+2i", "3-4i"},
+			true, "",
+			[]complex64{1 + 2i, 3 - 4i},
+		},
+		{
+			"NewValue",
+			[]complex64{1 + 2i}, []string{"3-4i"},
+			true, "",
+			[]complex64{3 - 4i},
+		},
+		{
+			"NoNewValue",
+			[]complex64{3 - 4i}, []string{"3-4i"},
+			false, "",
+			[]complex64{3 - 4i},
+		},
+		{
+			"InvalidValue",
+			[]complex64{1 + 2i}, []string{"invalid"},
+			false, `strconv.ParseComplex: parsing "invalid": invalid syntax`,
+			[]complex64{1 + 2i},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			v := reflect.ValueOf(&tc.c).Elem()
+			updated, err := r.setComplex64Slice(v, "Field", tc.vals)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.c)
+		})
+	}
+}
+
+func TestReaderSetComplex128Slice(t *testing.T) {
+	tests := []struct {
+		name            string
+		c               []complex128
+		vals            []string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  []complex128
+	}{
+		{
+			"Nil",
+			nil, []string{"1+2i", "3-4i"},
+			true, "",
+			[]complex128{1 + 2i, 3 - 4i},
+		},
+		{
+			"NewValue",
+			[]complex128{1 + 2i}, []string{"3-4i"},
+			true, "",
+			[]complex128{3 - 4i},
+		},
+		{
+			"NoNewValue",
+			[]complex128{3 - 4i}, []string{"3-4i"},
+			false, "",
+			[]complex128{3 - 4i},
+		},
+		{
+			"InvalidValue",
+			[]complex128{1 + 2i}, []string{"invalid"},
+			false, `strconv.ParseComplex: parsing "invalid": invalid syntax`,
+			[]complex128{1 + 2i},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			v := reflect.ValueOf(&tc.c).Elem()
+			updated, err := r.setComplex128Slice(v, "Field", tc.vals)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.c)
+		})
+	}
+}
+
 func TestReaderSetIntSlice(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -2342,6 +2659,47 @@ func TestReaderSetUint8Slice(t *testing.T) {
 	}
 }
 
+func TestReaderSetBytes(t *testing.T) {
+	tests := []struct {
+		name            string
+		b               []byte
+		val             string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  []byte
+	}{
+		{
+			"NewValue",
+			nil, "\x00\x01\xff\n",
+			true, "",
+			[]byte{0x00, 0x01, 0xff, '\n'},
+		},
+		{
+			"NoNewValue",
+			[]byte("secret"), "secret",
+			false, "",
+			[]byte("secret"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			v := reflect.ValueOf(&tc.b).Elem()
+			updated, err := r.setBytes(v, "Field", tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.b)
+		})
+	}
+}
+
 func TestReaderSetUint16Slice(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -2613,59 +2971,218 @@ func TestReaderSetStructSliceRegexp(t *testing.T) {
 	}
 }
 
+func TestReaderSetArray(t *testing.T) {
+	type fields struct {
+		Weights [3]float64
+		Hosts   [2]string
+		Bounds  [2]time.Duration
+		Octets  [4]uint8
+		Sizes   [2]int64
+		Servers [2]url.URL
+	}
+
+	url1, _ := url.Parse("service-1")
+	url2, _ := url.Parse("service-2")
+
+	tests := []struct {
+		name            string
+		s               fields
+		field           string
+		vals            []string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  fields
+	}{
+		{
+			name:            "NewValue",
+			field:           "Weights",
+			vals:            []string{"0.2", "0.3", "0.5"},
+			expectedUpdated: true,
+			expectedResult:  fields{Weights: [3]float64{0.2, 0.3, 0.5}},
+		},
+		{
+			name:            "NoNewValue",
+			s:               fields{Hosts: [2]string{"a", "b"}},
+			field:           "Hosts",
+			vals:            []string{"a", "b"},
+			expectedUpdated: false,
+			expectedResult:  fields{Hosts: [2]string{"a", "b"}},
+		},
+		{
+			name:            "Durations",
+			field:           "Bounds",
+			vals:            []string{"1s", "1m"},
+			expectedUpdated: true,
+			expectedResult:  fields{Bounds: [2]time.Duration{time.Second, time.Minute}},
+		},
+		{
+			name:            "Bytes",
+			field:           "Octets",
+			vals:            []string{"10", "0", "0", "1"},
+			expectedUpdated: true,
+			expectedResult:  fields{Octets: [4]uint8{10, 0, 0, 1}},
+		},
+		{
+			name:            "URLs",
+			field:           "Servers",
+			vals:            []string{"service-1", "service-2"},
+			expectedUpdated: true,
+			expectedResult:  fields{Servers: [2]url.URL{*url1, *url2}},
+		},
+		{
+			name:           "TooFewValues",
+			s:              fields{Weights: [3]float64{1, 2, 3}},
+			field:          "Weights",
+			vals:           []string{"0.5", "0.5"},
+			expectedError:  "invalid number of values: expected 3, got 2",
+			expectedResult: fields{Weights: [3]float64{1, 2, 3}},
+		},
+		{
+			name:           "TooManyValues",
+			field:          "Hosts",
+			vals:           []string{"a", "b", "c"},
+			expectedError:  "invalid number of values: expected 2, got 3",
+			expectedResult: fields{},
+		},
+		{
+			name:           "InvalidValue",
+			s:              fields{Sizes: [2]int64{1, 2}},
+			field:          "Sizes",
+			vals:           []string{"1", "invalid"},
+			expectedError:  `strconv.ParseInt: parsing "invalid": invalid syntax`,
+			expectedResult: fields{Sizes: [2]int64{1, 2}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			f := fieldInfo{
+				value:   reflect.ValueOf(&tc.s).Elem().FieldByName(tc.field),
+				name:    tc.field,
+				listSep: ",",
+			}
+
+			updated, err := r.setArray(f, tc.vals)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedResult, tc.s)
+		})
+	}
+}
+
+func TestPickArray(t *testing.T) {
+	type config struct {
+		Weights [3]float64
+	}
+
+	tests := []struct {
+		name           string
+		val            string
+		expectedError  string
+		expectedConfig config
+	}{
+		{
+			name:           "OK",
+			val:            "0.5,0.3,0.2",
+			expectedConfig: config{Weights: [3]float64{0.5, 0.3, 0.2}},
+		},
+		{
+			name:           "TooFewValues",
+			val:            "1,2",
+			expectedError:  "invalid values: Weights from environment variable WEIGHTS: invalid number of values: expected 3, got 2",
+			expectedConfig: config{Weights: [3]float64{1, 1, 1}},
+		},
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app"}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := os.Setenv("WEIGHTS", tc.val)
+			assert.NoError(t, err)
+			defer os.Unsetenv("WEIGHTS")
+
+			c := config{Weights: [3]float64{1, 1, 1}}
+			err = Pick(&c)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+			assert.Equal(t, tc.expectedConfig, c)
+		})
+	}
+}
+
 func TestReaderSetFieldValue(t *testing.T) {
 	type fields struct {
-		String        string
-		Bool          bool
-		Float32       float32
-		Float64       float64
-		Int           int
-		Int8          int8
-		Int16         int16
-		Int32         int32
-		Int64         int64
-		Uint          uint
-		Uint8         uint8
-		Uint16        uint16
-		Uint32        uint32
-		Uint64        uint64
-		Duration      time.Duration
-		URL           url.URL
-		Regexp        regexp.Regexp
-		StringPtr     *string
-		BoolPtr       *bool
-		Float32Ptr    *float32
-		Float64Ptr    *float64
-		IntPtr        *int
-		Int8Ptr       *int8
-		Int16Ptr      *int16
-		Int32Ptr      *int32
-		Int64Ptr      *int64
-		UintPtr       *uint
-		Uint8Ptr      *uint8
-		Uint16Ptr     *uint16
-		Uint32Ptr     *uint32
-		Uint64Ptr     *uint64
-		DurationPtr   *time.Duration
-		URLPtr        *url.URL
-		RegexpPtr     *regexp.Regexp
-		StringSlice   []string
-		BoolSlice     []bool
-		Float32Slice  []float32
-		Float64Slice  []float64
-		IntSlice      []int
-		Int8Slice     []int8
-		Int16Slice    []int16
-		Int32Slice    []int32
-		Int64Slice    []int64
-		UintSlice     []uint
-		Uint8Slice    []uint8
-		Uint16Slice   []uint16
-		Uint32Slice   []uint32
-		Uint64Slice   []uint64
-		DurationSlice []time.Duration
-		URLSlice      []url.URL
-		RegexpSlice   []regexp.Regexp
+		String          string
+		Bool            bool
+		Float32         float32
+		Float64         float64
+		Int             int
+		Int8            int8
+		Int16           int16
+		Int32           int32
+		Int64           int64
+		Uint            uint
+		Uint8           uint8
+		Uint16          uint16
+		Uint32          uint32
+		Uint64          uint64
+		Duration        time.Duration
+		URL             url.URL
+		Regexp          regexp.Regexp
+		StringPtr       *string
+		BoolPtr         *bool
+		Float32Ptr      *float32
+		Float64Ptr      *float64
+		IntPtr          *int
+		Int8Ptr         *int8
+		Int16Ptr        *int16
+		Int32Ptr        *int32
+		Int64Ptr        *int64
+		UintPtr         *uint
+		Uint8Ptr        *uint8
+		Uint16Ptr       *uint16
+		Uint32Ptr       *uint32
+		Uint64Ptr       *uint64
+		DurationPtr     *time.Duration
+		URLPtr          *url.URL
+		RegexpPtr       *regexp.Regexp
+		StringSlice     []string
+		BoolSlice       []bool
+		Float32Slice    []float32
+		Float64Slice    []float64
+		IntSlice        []int
+		Int8Slice       []int8
+		Int16Slice      []int16
+		Int32Slice      []int32
+		Int64Slice      []int64
+		UintSlice       []uint
+		Uint8Slice      []uint8
+		Uint16Slice     []uint16
+		Uint32Slice     []uint32
+		Uint64Slice     []uint64
+		DurationSlice   []time.Duration
+		URLSlice        []url.URL
+		RegexpSlice     []regexp.Regexp
+		Complex128      complex128
+		Complex64Ptr    *complex64
+		Complex128Slice []complex128
+		Float64Array    [3]float64
 	}
 
 	url1, _ := url.Parse("service-1")
@@ -2674,166 +3191,180 @@ func TestReaderSetFieldValue(t *testing.T) {
 	re1 := regexp.MustCompilePOSIX("[:digit:]")
 	re2 := regexp.MustCompilePOSIX("[:alpha:]")
 
+	c64 := complex64(1.5 + 2.5i)
+
 	f1 := fields{
-		String:        "old",
-		Bool:          false,
-		Float32:       3.1415,
-		Float64:       3.14159265359,
-		Int:           -9223372036854775808,
-		Int8:          -128,
-		Int16:         -32768,
-		Int32:         -2147483648,
-		Int64:         -9223372036854775808,
-		Uint:          0,
-		Uint8:         0,
-		Uint16:        0,
-		Uint32:        0,
-		Uint64:        0,
-		Duration:      time.Second,
-		URL:           *url1,
-		Regexp:        *re1,
-		StringPtr:     ptr.String("old"),
-		BoolPtr:       ptr.Bool(false),
-		Float32Ptr:    ptr.Float32(3.1415),
-		Float64Ptr:    ptr.Float64(3.14159265359),
-		IntPtr:        ptr.Int(-9223372036854775808),
-		Int8Ptr:       ptr.Int8(-128),
-		Int16Ptr:      ptr.Int16(-32768),
-		Int32Ptr:      ptr.Int32(-2147483648),
-		Int64Ptr:      ptr.Int64(-9223372036854775808),
-		UintPtr:       ptr.Uint(0),
-		Uint8Ptr:      ptr.Uint8(0),
-		Uint16Ptr:     ptr.Uint16(0),
-		Uint32Ptr:     ptr.Uint32(0),
-		Uint64Ptr:     ptr.Uint64(0),
-		DurationPtr:   ptr.Duration(time.Second),
-		URLPtr:        url1,
-		RegexpPtr:     re1,
-		StringSlice:   []string{"old"},
-		BoolSlice:     []bool{false},
-		Float32Slice:  []float32{3.1415},
-		Float64Slice:  []float64{3.14159265359},
-		IntSlice:      []int{-2147483648},
-		Int8Slice:     []int8{-128},
-		Int16Slice:    []int16{-32768},
-		Int32Slice:    []int32{-2147483648},
-		Int64Slice:    []int64{-9223372036854775808},
-		UintSlice:     []uint{0},
-		Uint8Slice:    []uint8{0},
-		Uint16Slice:   []uint16{0},
-		Uint32Slice:   []uint32{0},
-		Uint64Slice:   []uint64{0},
-		DurationSlice: []time.Duration{time.Second},
-		URLSlice:      []url.URL{*url1, *url2},
-		RegexpSlice:   []regexp.Regexp{*re1, *re2},
+		String:          "old",
+		Bool:            false,
+		Float32:         3.1415,
+		Float64:         3.14159265359,
+		Int:             -9223372036854775808,
+		Int8:            -128,
+		Int16:           -32768,
+		Int32:           -2147483648,
+		Int64:           -9223372036854775808,
+		Uint:            0,
+		Uint8:           0,
+		Uint16:          0,
+		Uint32:          0,
+		Uint64:          0,
+		Duration:        time.Second,
+		URL:             *url1,
+		Regexp:          *re1,
+		StringPtr:       ptr.String("old"),
+		BoolPtr:         ptr.Bool(false),
+		Float32Ptr:      ptr.Float32(3.1415),
+		Float64Ptr:      ptr.Float64(3.14159265359),
+		IntPtr:          ptr.Int(-9223372036854775808),
+		Int8Ptr:         ptr.Int8(-128),
+		Int16Ptr:        ptr.Int16(-32768),
+		Int32Ptr:        ptr.Int32(-2147483648),
+		Int64Ptr:        ptr.Int64(-9223372036854775808),
+		UintPtr:         ptr.Uint(0),
+		Uint8Ptr:        ptr.Uint8(0),
+		Uint16Ptr:       ptr.Uint16(0),
+		Uint32Ptr:       ptr.Uint32(0),
+		Uint64Ptr:       ptr.Uint64(0),
+		DurationPtr:     ptr.Duration(time.Second),
+		URLPtr:          url1,
+		RegexpPtr:       re1,
+		StringSlice:     []string{"old"},
+		BoolSlice:       []bool{false},
+		Float32Slice:    []float32{3.1415},
+		Float64Slice:    []float64{3.14159265359},
+		IntSlice:        []int{-2147483648},
+		Int8Slice:       []int8{-128},
+		Int16Slice:      []int16{-32768},
+		Int32Slice:      []int32{-2147483648},
+		Int64Slice:      []int64{-9223372036854775808},
+		UintSlice:       []uint{0},
+		Uint8Slice:      []uint8{0},
+		Uint16Slice:     []uint16{0},
+		Uint32Slice:     []uint32{0},
+		Uint64Slice:     []uint64{0},
+		DurationSlice:   []time.Duration{time.Second},
+		URLSlice:        []url.URL{*url1, *url2},
+		RegexpSlice:     []regexp.Regexp{*re1, *re2},
+		Complex128:      1 + 2i,
+		Complex64Ptr:    nil,
+		Complex128Slice: []complex128{1},
+		Float64Array:    [3]float64{1, 2, 3},
 	}
 
 	f2 := fields{
-		String:        "new",
-		Bool:          true,
-		Float32:       2.7182,
-		Float64:       2.7182818284,
-		Int:           9223372036854775807,
-		Int8:          127,
-		Int16:         32767,
-		Int32:         2147483647,
-		Int64:         9223372036854775807,
-		Uint:          18446744073709551615,
-		Uint8:         255,
-		Uint16:        65535,
-		Uint32:        4294967295,
-		Uint64:        18446744073709551615,
-		Duration:      time.Minute,
-		URL:           *url2,
-		Regexp:        *re2,
-		StringPtr:     ptr.String("new"),
-		BoolPtr:       ptr.Bool(true),
-		Float32Ptr:    ptr.Float32(2.7182),
-		Float64Ptr:    ptr.Float64(2.7182818284),
-		IntPtr:        ptr.Int(9223372036854775807),
-		Int8Ptr:       ptr.Int8(127),
-		Int16Ptr:      ptr.Int16(32767),
-		Int32Ptr:      ptr.Int32(2147483647),
-		Int64Ptr:      ptr.Int64(9223372036854775807),
-		UintPtr:       ptr.Uint(18446744073709551615),
-		Uint8Ptr:      ptr.Uint8(255),
-		Uint16Ptr:     ptr.Uint16(65535),
-		Uint32Ptr:     ptr.Uint32(4294967295),
-		Uint64Ptr:     ptr.Uint64(18446744073709551615),
-		DurationPtr:   ptr.Duration(time.Minute),
-		URLPtr:        url2,
-		RegexpPtr:     re2,
-		StringSlice:   []string{"new"},
-		BoolSlice:     []bool{true},
-		Float32Slice:  []float32{2.7182},
-		Float64Slice:  []float64{2.7182818284},
-		IntSlice:      []int{9223372036854775807},
-		Int8Slice:     []int8{127},
-		Int16Slice:    []int16{32767},
-		Int32Slice:    []int32{2147483647},
-		Int64Slice:    []int64{9223372036854775807},
-		UintSlice:     []uint{18446744073709551615},
-		Uint8Slice:    []uint8{255},
-		Uint16Slice:   []uint16{65535},
-		Uint32Slice:   []uint32{4294967295},
-		Uint64Slice:   []uint64{18446744073709551615},
-		DurationSlice: []time.Duration{time.Minute},
-		URLSlice:      []url.URL{*url2},
-		RegexpSlice:   []regexp.Regexp{*re2},
+		String:          "new",
+		Bool:            true,
+		Float32:         2.7182,
+		Float64:         2.7182818284,
+		Int:             9223372036854775807,
+		Int8:            127,
+		Int16:           32767,
+		Int32:           2147483647,
+		Int64:           9223372036854775807,
+		Uint:            18446744073709551615,
+		Uint8:           255,
+		Uint16:          65535,
+		Uint32:          4294967295,
+		Uint64:          18446744073709551615,
+		Duration:        time.Minute,
+		URL:             *url2,
+		Regexp:          *re2,
+		StringPtr:       ptr.String("new"),
+		BoolPtr:         ptr.Bool(true),
+		Float32Ptr:      ptr.Float32(2.7182),
+		Float64Ptr:      ptr.Float64(2.7182818284),
+		IntPtr:          ptr.Int(9223372036854775807),
+		Int8Ptr:         ptr.Int8(127),
+		Int16Ptr:        ptr.Int16(32767),
+		Int32Ptr:        ptr.Int32(2147483647),
+		Int64Ptr:        ptr.Int64(9223372036854775807),
+		UintPtr:         ptr.Uint(18446744073709551615),
+		Uint8Ptr:        ptr.Uint8(255),
+		Uint16Ptr:       ptr.Uint16(65535),
+		Uint32Ptr:       ptr.Uint32(4294967295),
+		Uint64Ptr:       ptr.Uint64(18446744073709551615),
+		DurationPtr:     ptr.Duration(time.Minute),
+		URLPtr:          url2,
+		RegexpPtr:       re2,
+		StringSlice:     []string{"new"},
+		BoolSlice:       []bool{true},
+		Float32Slice:    []float32{2.7182},
+		Float64Slice:    []float64{2.7182818284},
+		IntSlice:        []int{9223372036854775807},
+		Int8Slice:       []int8{127},
+		Int16Slice:      []int16{32767},
+		Int32Slice:      []int32{2147483647},
+		Int64Slice:      []int64{9223372036854775807},
+		UintSlice:       []uint{18446744073709551615},
+		Uint8Slice:      []uint8{255},
+		Uint16Slice:     []uint16{65535},
+		Uint32Slice:     []uint32{4294967295},
+		Uint64Slice:     []uint64{18446744073709551615},
+		DurationSlice:   []time.Duration{time.Minute},
+		URLSlice:        []url.URL{*url2},
+		RegexpSlice:     []regexp.Regexp{*re2},
+		Complex128:      3 - 4i,
+		Complex64Ptr:    &c64,
+		Complex128Slice: []complex128{1 + 1i, 2i},
+		Float64Array:    [3]float64{0.2, 0.3, 0.5},
 	}
 
 	values := map[string]string{
-		"String":        "new",
-		"Bool":          "true",
-		"Float32":       "2.7182",
-		"Float64":       "2.7182818284",
-		"Int":           "9223372036854775807",
-		"Int8":          "127",
-		"Int16":         "32767",
-		"Int32":         "2147483647",
-		"Int64":         "9223372036854775807",
-		"Uint":          "18446744073709551615",
-		"Uint8":         "255",
-		"Uint16":        "65535",
-		"Uint32":        "4294967295",
-		"Uint64":        "18446744073709551615",
-		"Duration":      "1m",
-		"URL":           "service-2",
-		"Regexp":        "[:alpha:]",
-		"StringPtr":     "new",
-		"BoolPtr":       "true",
-		"Float32Ptr":    "2.7182",
-		"Float64Ptr":    "2.7182818284",
-		"IntPtr":        "9223372036854775807",
-		"Int8Ptr":       "127",
-		"Int16Ptr":      "32767",
-		"Int32Ptr":      "2147483647",
-		"Int64Ptr":      "9223372036854775807",
-		"UintPtr":       "18446744073709551615",
-		"Uint8Ptr":      "255",
-		"Uint16Ptr":     "65535",
-		"Uint32Ptr":     "4294967295",
-		"Uint64Ptr":     "18446744073709551615",
-		"DurationPtr":   "1m",
-		"URLPtr":        "service-2",
-		"RegexpPtr":     "[:alpha:]",
-		"StringSlice":   "new",
-		"BoolSlice":     "true",
-		"Float32Slice":  "2.7182",
-		"Float64Slice":  "2.7182818284",
-		"IntSlice":      "9223372036854775807",
-		"Int8Slice":     "127",
-		"Int16Slice":    "32767",
-		"Int32Slice":    "2147483647",
-		"Int64Slice":    "9223372036854775807",
-		"UintSlice":     "18446744073709551615",
-		"Uint8Slice":    "255",
-		"Uint16Slice":   "65535",
-		"Uint32Slice":   "4294967295",
-		"Uint64Slice":   "18446744073709551615",
-		"DurationSlice": "1m",
-		"URLSlice":      "service-2",
-		"RegexpSlice":   "[:alpha:]",
+		"String":          "new",
+		"Bool":            "true",
+		"Float32":         "2.7182",
+		"Float64":         "2.7182818284",
+		"Int":             "9223372036854775807",
+		"Int8":            "127",
+		"Int16":           "32767",
+		"Int32":           "2147483647",
+		"Int64":           "9223372036854775807",
+		"Uint":            "18446744073709551615",
+		"Uint8":           "255",
+		"Uint16":          "65535",
+		"Uint32":          "4294967295",
+		"Uint64":          "18446744073709551615",
+		"Duration":        "1m",
+		"URL":             "service-2",
+		"Regexp":          "[:alpha:]",
+		"StringPtr":       "new",
+		"BoolPtr":         "true",
+		"Float32Ptr":      "2.7182",
+		"Float64Ptr":      "2.7182818284",
+		"IntPtr":          "9223372036854775807",
+		"Int8Ptr":         "127",
+		"Int16Ptr":        "32767",
+		"Int32Ptr":        "2147483647",
+		"Int64Ptr":        "9223372036854775807",
+		"UintPtr":         "18446744073709551615",
+		"Uint8Ptr":        "255",
+		"Uint16Ptr":       "65535",
+		"Uint32Ptr":       "4294967295",
+		"Uint64Ptr":       "18446744073709551615",
+		"DurationPtr":     "1m",
+		"URLPtr":          "service-2",
+		"RegexpPtr":       "[:alpha:]",
+		"StringSlice":     "new",
+		"BoolSlice":       "true",
+		"Float32Slice":    "2.7182",
+		"Float64Slice":    "2.7182818284",
+		"IntSlice":        "9223372036854775807",
+		"Int8Slice":       "127",
+		"Int16Slice":      "32767",
+		"Int32Slice":      "2147483647",
+		"Int64Slice":      "9223372036854775807",
+		"UintSlice":       "18446744073709551615",
+		"Uint8Slice":      "255",
+		"Uint16Slice":     "65535",
+		"Uint32Slice":     "4294967295",
+		"Uint64Slice":     "18446744073709551615",
+		"DurationSlice":   "1m",
+		"URLSlice":        "service-2",
+		"RegexpSlice":     "[:alpha:]",
+		"Complex128":      "3-4i",
+		"Complex64Ptr":    "1.5+2.5i",
+		"Complex128Slice": "1+1i,2i",
+		"Float64Array":    "0.2,0.3,0.5",
 	}
 
 	tests := []struct {
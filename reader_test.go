@@ -1,11 +1,15 @@
 package konfig
 
 import (
+	"bytes"
 	"flag"
 	"io/ioutil"
+	"log"
 	"os"
 	"reflect"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/moorara/konfig/ptr"
 	"github.com/stretchr/testify/assert"
@@ -280,6 +284,26 @@ func TestReaderFromEnv(t *testing.T) {
 				filesToFields: map[string]fieldInfo{},
 			},
 		},
+		{
+			name: "PollInterval",
+			env: map[string]string{
+				envPollInterval: "5s",
+			},
+			expectedReader: &reader{
+				debug:         0,
+				listSep:       ",",
+				skipFlag:      false,
+				skipEnv:       false,
+				skipFileEnv:   false,
+				prefixFlag:    "",
+				prefixEnv:     "",
+				prefixFileEnv: "",
+				telepresence:  false,
+				pollInterval:  5 * time.Second,
+				subscribers:   nil,
+				filesToFields: map[string]fieldInfo{},
+			},
+		},
 		{
 			name: "AllOptions",
 			env: map[string]string{
@@ -291,7 +315,15 @@ func TestReaderFromEnv(t *testing.T) {
 				envPrefixFlag:    "config.",
 				envPrefixEnv:     "CONFIG_",
 				envPrefixFileEnv: "CONFIG_",
+				envFlagNaming:    "kebab",
+				envEnvNaming:     "screaming_snake",
+				envFileEnvNaming: "snake",
+				envAcronyms:      "GitHub, K8s, default",
 				envTelepresence:  "true",
+				envPollInterval:  "5s",
+				envExpand:        "true",
+				envFileTrim:      "space",
+				envStrict:        "true",
 			},
 			expectedReader: &reader{
 				debug:         3,
@@ -302,8 +334,16 @@ func TestReaderFromEnv(t *testing.T) {
 				prefixFlag:    "config.",
 				prefixEnv:     "CONFIG_",
 				prefixFileEnv: "CONFIG_",
+				namingFlag:    KebabCase,
+				namingEnv:     ScreamingSnakeCase,
+				namingFileEnv: SnakeCase,
+				acronyms:      []string{"GitHub", "K8s", "OAuth", "GraphQL", "gRPC", "IPv4", "IPv6", "MySQL", "PostgreSQL"},
 				telepresence:  true,
+				pollInterval:  5 * time.Second,
 				subscribers:   nil,
+				expand:        true,
+				fileTrim:      TrimSpace,
+				strict:        true,
 				filesToFields: map[string]fieldInfo{},
 			},
 		},
@@ -396,6 +436,72 @@ func TestReaderString(t *testing.T) {
 			},
 			"Telepresence",
 		},
+		{
+			"WithPollInterval",
+			&reader{
+				pollInterval: time.Second,
+			},
+			"PollInterval<1s>",
+		},
+		{
+			"WithReloadSignals",
+			&reader{
+				reloadSignals: []os.Signal{syscall.SIGHUP, os.Interrupt},
+			},
+			"ReloadSignals<hangup,interrupt>",
+		},
+		{
+			"WithBackpressure",
+			&reader{
+				backpressure: BackpressureCoalesce,
+				queueSize:    10,
+			},
+			"Backpressure<Coalesce,10>",
+		},
+		{
+			"WithLogger",
+			&reader{
+				logger: LoggerFunc(func(uint, string, ...interface{}) {}),
+			},
+			"Logger",
+		},
+		{
+			"WithExpand",
+			&reader{
+				expand: true,
+			},
+			"Expand",
+		},
+		{
+			"WithFileTrim",
+			&reader{
+				fileTrim: TrimNone,
+			},
+			"FileTrim<None>",
+		},
+		{
+			"WithStrict",
+			&reader{
+				strict: true,
+			},
+			"Strict",
+		},
+		{
+			"WithNamings",
+			&reader{
+				namingFlag:    KebabCase,
+				namingEnv:     NamingFunc(func(words []string) string { return "" }),
+				namingFileEnv: SnakeCase,
+			},
+			"FlagNaming<Kebab> + EnvNaming<Custom> + FileEnvNaming<Snake>",
+		},
+		{
+			"WithAcronyms",
+			&reader{
+				acronyms: []string{"GitHub", "K8s"},
+			},
+			"Acronyms<GitHub,K8s>",
+		},
 		{
 			"WithSubscribers",
 			&reader{
@@ -418,12 +524,15 @@ func TestReaderString(t *testing.T) {
 				skipEnv:       true,
 				skipFileEnv:   true,
 				telepresence:  true,
+				pollInterval:  time.Second,
+				backpressure:  BackpressureDropOldest,
+				queueSize:     10,
 				subscribers: []chan Update{
 					make(chan Update),
 					make(chan Update),
 				},
 			},
-			"Debug<2> + ListSep<|> + SkipFlag + SkipEnv + SkipFileEnv + PrefixFlag<config.> + PrefixEnv<CONFIG_> + PrefixFileEnv<CONFIG_> + Telepresence + Subscribers<2>",
+			"Debug<2> + ListSep<|> + SkipFlag + SkipEnv + SkipFileEnv + PrefixFlag<config.> + PrefixEnv<CONFIG_> + PrefixFileEnv<CONFIG_> + Telepresence + PollInterval<1s> + Backpressure<DropOldest,10> + Subscribers<2>",
 		},
 	}
 
@@ -435,12 +544,25 @@ func TestReaderString(t *testing.T) {
 }
 
 func TestReaderLog(t *testing.T) {
-	tests := []struct {
-		name      string
-		r         *reader
+	type entry struct {
 		verbosity uint
 		msg       string
-		args      []interface{}
+		keyvals   []interface{}
+	}
+
+	var entries []entry
+	logger := LoggerFunc(func(verbosity uint, msg string, keyvals ...interface{}) {
+		entries = append(entries, entry{verbosity, msg, keyvals})
+	})
+
+	tests := []struct {
+		name            string
+		r               *reader
+		verbosity       uint
+		msg             string
+		keyvals         []interface{}
+		expectedOutput  string
+		expectedEntries []entry
 	}{
 		{
 			"WithoutDebug",
@@ -448,6 +570,8 @@ func TestReaderLog(t *testing.T) {
 			1,
 			"testing ...",
 			nil,
+			"",
+			nil,
 		},
 		{
 			"WithDebug",
@@ -456,13 +580,42 @@ func TestReaderLog(t *testing.T) {
 			},
 			2,
 			"testing ...",
+			[]interface{}{"field", "Field", "source", SourceEnv},
+			"[Field] testing ... source=env\n",
 			nil,
 		},
+		{
+			"WithLogger",
+			&reader{
+				logger: logger,
+			},
+			5,
+			"testing ...",
+			[]interface{}{"field", "Field", "path", "/path/to/file"},
+			"",
+			[]entry{
+				{5, "testing ...", []interface{}{"field", "Field", "path", "/path/to/file"}},
+			},
+		},
 	}
 
+	origFlags, origWriter := log.Flags(), log.Writer()
+	defer func() {
+		log.SetFlags(origFlags)
+		log.SetOutput(origWriter)
+	}()
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.r.log(tc.verbosity, tc.msg, tc.args...)
+			entries = nil
+			buf := new(bytes.Buffer)
+			log.SetFlags(0)
+			log.SetOutput(buf)
+
+			tc.r.log(tc.verbosity, tc.msg, tc.keyvals...)
+
+			assert.Equal(t, tc.expectedOutput, buf.String())
+			assert.Equal(t, tc.expectedEntries, entries)
 		})
 	}
 }
@@ -486,6 +639,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 		fieldName, flagName, envName, fileEnvName string
 		r                                         *reader
 		expectedValue                             string
+		expectedSource                            Source
 		expectFilePath                            bool
 	}{
 		{
@@ -496,6 +650,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "-", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{},
 			"info",
+			SourceEnv,
 			false,
 		},
 		{
@@ -506,6 +661,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "-", "-", "LOG_LEVEL_FILE",
 			&reader{},
 			"error",
+			SourceFile,
 			true,
 		},
 		{
@@ -516,6 +672,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "-", "-", "-",
 			&reader{},
 			"",
+			SourceDefault,
 			false,
 		},
 		{
@@ -528,6 +685,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 				skipFlag: true,
 			},
 			"info",
+			SourceEnv,
 			false,
 		},
 		{
@@ -541,6 +699,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 				skipEnv:  true,
 			},
 			"error",
+			SourceFile,
 			true,
 		},
 		{
@@ -555,6 +714,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 				skipFileEnv: true,
 			},
 			"",
+			SourceDefault,
 			false,
 		},
 		{
@@ -565,6 +725,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{},
 			"debug",
+			SourceFlag,
 			false,
 		},
 		{
@@ -575,6 +736,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{},
 			"debug",
+			SourceFlag,
 			false,
 		},
 		{
@@ -585,6 +747,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{},
 			"debug",
+			SourceFlag,
 			false,
 		},
 		{
@@ -595,6 +758,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{},
 			"debug",
+			SourceFlag,
 			false,
 		},
 		{
@@ -605,6 +769,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{},
 			"info",
+			SourceEnv,
 			false,
 		},
 		{
@@ -615,6 +780,7 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{},
 			"error",
+			SourceFile,
 			true,
 		},
 		{
@@ -625,6 +791,40 @@ func TestReaderGetFieldValue(t *testing.T) {
 			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
 			&reader{telepresence: true},
 			"info",
+			SourceFile,
+			true,
+		},
+		{
+			"FromFileWithTrailingNewline",
+			[]string{"/path/to/executable"},
+			env{"LOG_LEVEL", ""},
+			file{"LOG_LEVEL_FILE", "info\r\n"},
+			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
+			&reader{},
+			"info",
+			SourceFile,
+			true,
+		},
+		{
+			"FromFileWithTrimSpace",
+			[]string{"/path/to/executable"},
+			env{"LOG_LEVEL", ""},
+			file{"LOG_LEVEL_FILE", "  info\n\n"},
+			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
+			&reader{fileTrim: TrimSpace},
+			"info",
+			SourceFile,
+			true,
+		},
+		{
+			"FromFileWithTrimNone",
+			[]string{"/path/to/executable"},
+			env{"LOG_LEVEL", ""},
+			file{"LOG_LEVEL_FILE", "info\n"},
+			"Field", "log.level", "LOG_LEVEL", "LOG_LEVEL_FILE",
+			&reader{fileTrim: TrimNone},
+			"info\n",
+			SourceFile,
 			true,
 		},
 	}
@@ -668,8 +868,16 @@ func TestReaderGetFieldValue(t *testing.T) {
 			defer os.Unsetenv(tc.fileConfig.varName)
 
 			// Verify
-			value, filePath := tc.r.getFieldValue(tc.fieldName, tc.flagName, tc.envName, tc.fileEnvName)
+			p := &fieldPlan{
+				name:        tc.fieldName,
+				flagName:    tc.flagName,
+				envName:     tc.envName,
+				fileEnvName: tc.fileEnvName,
+			}
+
+			value, source, filePath, _ := tc.r.getFieldValue(p)
 			assert.Equal(t, tc.expectedValue, value)
+			assert.Equal(t, tc.expectedSource, source)
 			if tc.expectFilePath {
 				assert.Equal(t, tmpfile.Name(), filePath)
 			}
@@ -725,6 +933,7 @@ func TestNotifySubscribers(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			defer tc.r.closeQueues()
 			tc.r.notifySubscribers(tc.fieldName, tc.fieldValue)
 
 			if tc.expectedUpdate != (Update{}) {
@@ -798,12 +1007,12 @@ func TestIterateOnFields(t *testing.T) {
 			vStruct, err := validateStruct(tc.s)
 			assert.NoError(t, err)
 
-			tc.r.iterateOnFields(vStruct, func(v reflect.Value, fieldName, flagName, envName, fileEnvName, listSep string) {
-				fieldNames = append(fieldNames, fieldName)
-				flagNames = append(flagNames, flagName)
-				envNames = append(envNames, envName)
-				fileEnvNames = append(fileEnvNames, fileEnvName)
-				listSeps = append(listSeps, listSep)
+			tc.r.iterateOnFields(vStruct, func(v reflect.Value, p *fieldPlan) {
+				fieldNames = append(fieldNames, p.name)
+				flagNames = append(flagNames, p.flagName)
+				envNames = append(envNames, p.envName)
+				fileEnvNames = append(fileEnvNames, p.fileEnvName)
+				listSeps = append(listSeps, p.listSep)
 			})
 
 			assert.Equal(t, tc.expectedFieldNames, fieldNames)
@@ -823,6 +1032,7 @@ func TestRegisterFlags(t *testing.T) {
 		IntPointer    *int
 		StringSlice   []string
 		IntSlice      []int
+		Aliased       string `flag:",aliased.new,aliased.old" deprecated:"aliased.old"`
 	}
 
 	tests := []struct {
@@ -837,7 +1047,7 @@ func TestRegisterFlags(t *testing.T) {
 			r:             &reader{},
 			s:             &fields{},
 			expectedError: nil,
-			expectedFlags: []string{"string", "int", "string.pointer", "int.pointer", "string.slice", "int.slice"},
+			expectedFlags: []string{"string", "int", "string.pointer", "int.pointer", "string.slice", "int.slice", "aliased", "aliased.new", "aliased.old"},
 		},
 		{
 			name: "WithPrefixFlagOption",
@@ -846,7 +1056,7 @@ func TestRegisterFlags(t *testing.T) {
 			},
 			s:             &fields{},
 			expectedError: nil,
-			expectedFlags: []string{"config.string", "config.int", "config.string.pointer", "config.int.pointer", "config.string.slice", "config.int.slice"},
+			expectedFlags: []string{"config.string", "config.int", "config.string.pointer", "config.int.pointer", "config.string.slice", "config.int.slice", "config.aliased", "aliased.new", "aliased.old"},
 		},
 	}
 
@@ -0,0 +1,210 @@
+package konfig
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a number of bytes that can be read in a human-readable format (i.e. 512KiB or 10MB).
+type ByteSize uint64
+
+// Binary and decimal byte sizes.
+const (
+	Byte ByteSize = 1
+
+	KiB = 1024 * Byte
+	MiB = 1024 * KiB
+	GiB = 1024 * MiB
+	TiB = 1024 * GiB
+	PiB = 1024 * TiB
+	EiB = 1024 * PiB
+
+	KB = 1000 * Byte
+	MB = 1000 * KB
+	GB = 1000 * MB
+	TB = 1000 * GB
+	PB = 1000 * TB
+	EB = 1000 * PB
+)
+
+// byteUnits are the units for byte sizes with B in lower case.
+var byteUnits = map[string]ByteSize{
+	"b": Byte,
+
+	"kib": KiB,
+	"mib": MiB,
+	"gib": GiB,
+	"tib": TiB,
+	"pib": PiB,
+	"eib": EiB,
+
+	"kb": KB,
+	"mb": MB,
+	"gb": GB,
+	"tb": TB,
+	"pb": PB,
+	"eb": EB,
+}
+
+// byteSuffixes are the units for byte sizes without B.
+// They are case-sensitive the same as Kubernetes quantities (i.e. k is kilo, but m is milli and not mega).
+var byteSuffixes = map[string]ByteSize{
+	"": Byte,
+
+	"Ki": KiB,
+	"Mi": MiB,
+	"Gi": GiB,
+	"Ti": TiB,
+	"Pi": PiB,
+	"Ei": EiB,
+
+	"k": KB,
+	"M": MB,
+	"G": GB,
+	"T": TB,
+	"P": PB,
+	"E": EB,
+}
+
+// ParseByteSize parses a byte size consisting of a number and an optional unit (i.e. 1.5GiB or 10 MB).
+// KiB, MiB, GiB, TiB, PiB, and EiB are powers of 1024 and KB, MB, GB, TB, PB, and EB are powers of 1000.
+// Units with B are case-insensitive. Units without B are the same as Kubernetes quantities and are case-sensitive (i.e. 512Ki or 10M).
+// A number without a unit is a number of bytes.
+func ParseByteSize(s string) (ByteSize, error) {
+	num, unit := splitNumber(strings.TrimSpace(s))
+	unit = strings.TrimSpace(unit)
+
+	mult, ok := byteSuffixes[unit]
+	if !ok {
+		mult, ok = byteUnits[strings.ToLower(unit)]
+	}
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size: %s", s)
+	}
+
+	r, ok := new(big.Rat).SetString(num)
+	if !ok || r.Sign() < 0 {
+		return 0, fmt.Errorf("invalid byte size: %s", s)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(mult))))
+	if !r.IsInt() || !r.Num().IsUint64() {
+		return 0, fmt.Errorf("invalid byte size: %s", s)
+	}
+
+	return ByteSize(r.Num().Uint64()), nil
+}
+
+// String returns the byte size using the largest unit that the size is a whole multiple of (i.e. 1536 is 1536B and 1048576 is 1MiB).
+func (b ByteSize) String() string {
+	if b == 0 {
+		return "0B"
+	}
+
+	units := []struct {
+		size ByteSize
+		name string
+	}{
+		{EiB, "EiB"}, {PiB, "PiB"}, {TiB, "TiB"}, {GiB, "GiB"}, {MiB, "MiB"}, {KiB, "KiB"},
+		{EB, "EB"}, {PB, "PB"}, {TB, "TB"}, {GB, "GB"}, {MB, "MB"}, {KB, "KB"},
+	}
+
+	for _, u := range units {
+		if b%u.size == 0 {
+			return fmt.Sprintf("%d%s", b/u.size, u.name)
+		}
+	}
+
+	return fmt.Sprintf("%dB", uint64(b))
+}
+
+// Quantity is a number that can be read in the format of Kubernetes quantities (i.e. 500m or 1.5Gi).
+type Quantity float64
+
+// quantitySuffixes are the multipliers for the suffixes of Kubernetes quantities.
+var quantitySuffixes = map[string]float64{
+	"n": 1e-9,
+	"u": 1e-6,
+	"m": 1e-3,
+	"k": 1e3,
+	"M": 1e6,
+	"G": 1e9,
+	"T": 1e12,
+	"P": 1e15,
+	"E": 1e18,
+
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+	"Pi": 1 << 50,
+	"Ei": 1 << 60,
+}
+
+// ParseQuantity parses a Kubernetes quantity consisting of a number and an optional suffix (i.e. 500m, 2k, 1.5Gi, or 1e3).
+// Unlike byte sizes, suffixes are case-sensitive (m is milli and M is mega).
+func ParseQuantity(s string) (Quantity, error) {
+	num, suffix := splitNumber(s)
+
+	// Exponents (i.e. 1e3) are also a valid number
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return Quantity(f), nil
+	}
+
+	mult, ok := quantitySuffixes[suffix]
+	if !ok || num == "" {
+		return 0, fmt.Errorf("invalid quantity: %s", s)
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity: %s", s)
+	}
+
+	return Quantity(f * mult), nil
+}
+
+// String returns the quantity as a plain number.
+func (q Quantity) String() string {
+	return strconv.FormatFloat(float64(q), 'g', -1, 64)
+}
+
+// splitNumber splits a string into a leading decimal number (i.e. -1.5) and the rest of it.
+func splitNumber(s string) (string, string) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	for i < len(s) && (s[i] == '.' || ('0' <= s[i] && s[i] <= '9')) {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
+
+// isUnit determines whether or not a unit can be used in unit tag.
+func isUnit(unit string) bool {
+	return unit == "bytes"
+}
+
+// convertUnit converts a value in a unit specified by unit tag to a plain number, so it can be set on a numeric field.
+// For lists, every item is converted separately.
+func convertUnit(unit, val, listSep string) (string, error) {
+	if unit != "bytes" {
+		return "", fmt.Errorf("unknown unit: %s", unit)
+	}
+
+	vals := strings.Split(val, listSep)
+	for i, v := range vals {
+		size, err := ParseByteSize(v)
+		if err != nil {
+			return "", err
+		}
+		vals[i] = strconv.FormatUint(uint64(size), 10)
+	}
+
+	return strings.Join(vals, listSep), nil
+}
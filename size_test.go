@@ -0,0 +1,212 @@
+package konfig
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             string
+		expectedSize  ByteSize
+		expectedError error
+	}{
+		{"Bytes", "1024", 1024, nil},
+		{"BytesUnit", "512B", 512, nil},
+		{"Binary", "512KiB", 512 * KiB, nil},
+		{"BinaryWithoutB", "512Ki", 512 * KiB, nil},
+		{"Decimal", "10MB", 10 * MB, nil},
+		{"DecimalWithoutB", "10M", 10 * MB, nil},
+		{"Fraction", "1.5GiB", 3 * GiB / 2, nil},
+		{"Space", " 2 TB ", 2 * TB, nil},
+		{"CaseInsensitive", "4gib", 4 * GiB, nil},
+		{"KiloWithoutB", "2k", 2 * KB, nil},
+		{"Milli", "500m", 0, errors.New("invalid byte size: 500m")},
+		{"LowerCaseWithoutB", "512ki", 0, errors.New("invalid byte size: 512ki")},
+		{"UpperCaseKiloWithoutB", "2K", 0, errors.New("invalid byte size: 2K")},
+		{"Max", "18446744073709551615", ByteSize(1<<64 - 1), nil},
+		{"Empty", "", 0, errors.New("invalid byte size: ")},
+		{"NoNumber", "KiB", 0, errors.New("invalid byte size: KiB")},
+		{"UnknownUnit", "10XB", 0, errors.New("invalid byte size: 10XB")},
+		{"Negative", "-1KiB", 0, errors.New("invalid byte size: -1KiB")},
+		{"FractionOfByte", "1.5B", 0, errors.New("invalid byte size: 1.5B")},
+		{"Overflow", "16EiB", 0, errors.New("invalid byte size: 16EiB")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := ParseByteSize(tc.s)
+
+			assert.Equal(t, tc.expectedSize, size)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		name           string
+		size           ByteSize
+		expectedString string
+	}{
+		{"Zero", 0, "0B"},
+		{"Bytes", 1536, "1536B"},
+		{"Binary", 512 * KiB, "512KiB"},
+		{"Decimal", 10 * MB, "10MB"},
+		{"Large", 3 * EiB, "3EiB"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.size.String())
+
+			size, err := ParseByteSize(tc.size.String())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.size, size)
+		})
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		name             string
+		s                string
+		expectedQuantity Quantity
+		expectedError    error
+	}{
+		{"Integer", "2", 2, nil},
+		{"Fraction", "0.5", 0.5, nil},
+		{"Exponent", "1e3", 1000, nil},
+		{"Milli", "500m", 0.5, nil},
+		{"Micro", "250u", 0.00025, nil},
+		{"Kilo", "2k", 2000, nil},
+		{"Mega", "3M", 3e6, nil},
+		{"Exa", "1E", 1e18, nil},
+		{"Binary", "1.5Gi", 1.5 * (1 << 30), nil},
+		{"Negative", "-100m", -0.1, nil},
+		{"Empty", "", 0, errors.New("invalid quantity: ")},
+		{"NoNumber", "Mi", 0, errors.New("invalid quantity: Mi")},
+		{"UnknownSuffix", "2x", 0, errors.New("invalid quantity: 2x")},
+		{"CaseSensitive", "1K", 0, errors.New("invalid quantity: 1K")},
+		{"Infinity", "Inf", 0, errors.New("invalid quantity: Inf")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := ParseQuantity(tc.s)
+
+			assert.InDelta(t, float64(tc.expectedQuantity), float64(q), 1e-12)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestQuantityString(t *testing.T) {
+	assert.Equal(t, "0.5", Quantity(0.5).String())
+	assert.Equal(t, "1024", Quantity(1024).String())
+}
+
+func TestConvertUnit(t *testing.T) {
+	tests := []struct {
+		name          string
+		unit          string
+		val           string
+		listSep       string
+		expectedValue string
+		expectedError error
+	}{
+		{"Bytes", "bytes", "512KiB", ",", "524288", nil},
+		{"List", "bytes", "1KiB|1KB|1", "|", "1024|1000|1", nil},
+		{"InvalidValue", "bytes", "1KiB,invalid", ",", "", errors.New("invalid byte size: invalid")},
+		{"UnknownUnit", "seconds", "10", ",", "", errors.New("unknown unit: seconds")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := convertUnit(tc.unit, tc.val, tc.listSep)
+
+			assert.Equal(t, tc.expectedValue, val)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestReaderSetFieldValueWithUnit(t *testing.T) {
+	type fields struct {
+		Int       int
+		UintPtr   *uint64
+		Int64List []int64
+		Float     float64
+		Size      ByteSize
+		SizeList  []ByteSize
+		Quantity  Quantity
+	}
+
+	size := uint64(10 * MB)
+
+	tests := []struct {
+		name            string
+		field           string
+		unit            string
+		val             string
+		expectedUpdated bool
+		expectedError   bool
+		expectedResult  fields
+	}{
+		{"Int", "Int", "bytes", "512KiB", true, false, fields{Int: 524288}},
+		{"UintPtr", "UintPtr", "bytes", "10MB", true, false, fields{UintPtr: &size}},
+		{"Int64List", "Int64List", "bytes", "1Ki,1k", true, false, fields{Int64List: []int64{1024, 1000}}},
+		{"Float", "Float", "bytes", "1.5KiB", true, false, fields{Float: 1536}},
+		{"InvalidValue", "Int", "bytes", "10XB", false, true, fields{}},
+		{"UnknownUnit", "Int", "seconds", "10", false, true, fields{}},
+		{"ByteSize", "Size", "", "1.5GiB", true, false, fields{Size: 3 * GiB / 2}},
+		{"ByteSizeList", "SizeList", "", "1KiB,1KB", true, false, fields{SizeList: []ByteSize{KiB, KB}}},
+		{"InvalidByteSize", "Size", "", "1.5B", false, true, fields{}},
+		{"Quantity", "Quantity", "", "500m", true, false, fields{Quantity: 0.5}},
+		{"InvalidQuantity", "Quantity", "", "500x", false, true, fields{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := fields{}
+			f := fieldInfo{
+				value:   reflect.ValueOf(&s).Elem().FieldByName(tc.field),
+				name:    tc.field,
+				listSep: ",",
+				unit:    tc.unit,
+			}
+
+			updated, err := new(reader).setFieldValue(f, tc.val)
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedError, err != nil)
+			assert.Equal(t, tc.expectedResult, s)
+		})
+	}
+}
+
+func TestPickUnknownUnit(t *testing.T) {
+	type upstream struct {
+		Buffer int `unit:"kb"`
+	}
+
+	type config struct {
+		UploadLimit int64 `unit:"byte"`
+		Upstreams   []upstream
+	}
+
+	err := os.Setenv("UPLOAD_LIMIT", "10MB")
+	assert.NoError(t, err)
+	defer os.Unsetenv("UPLOAD_LIMIT")
+
+	c := &config{}
+	err = Pick(c)
+
+	assert.EqualError(t, err, "invalid tags: UploadLimit: unknown unit: byte; Upstreams[].Buffer: unknown unit: kb")
+	assert.Equal(t, &config{}, c)
+}
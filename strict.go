@@ -0,0 +1,309 @@
+package konfig
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// unsupportedField is an exported struct field that cannot be read.
+type unsupportedField struct {
+	name   string
+	typ    string
+	reason string
+}
+
+func (f unsupportedField) String() string {
+	return fmt.Sprintf("%s (%s): %s", f.name, f.typ, f.reason)
+}
+
+// lockerType is the type of sync.Locker interface.
+var lockerType = reflect.TypeOf((*sync.Locker)(nil)).Elem()
+
+// isIgnored determines whether or not a struct field is opted out of reading values using `konfig:"-"` tag.
+func isIgnored(f reflect.StructField) bool {
+	return f.Tag.Get(tagKonfig) == skip
+}
+
+// isUnsupported determines whether or not an exported struct field cannot be read and should be reported.
+// Embedded lockers (i.e. sync.Mutex) are never reported, since they are needed for Watch.
+func isUnsupported(f reflect.StructField) bool {
+	if isIgnored(f) || isTypeSupported(f.Type) || f.Tag.Get(tagFormat) != "" {
+		return false
+	}
+
+	return !f.Anonymous || !reflect.PtrTo(f.Type).Implements(lockerType)
+}
+
+// unsupportedReason returns why a type cannot be read.
+func unsupportedReason(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Map:
+		return "maps can only be read using format tag"
+	case reflect.Struct:
+		return "structs can only be read using format tag"
+	case reflect.Ptr:
+		if isNamedBasic(t.Elem()) {
+			return "pointers to named types can only be read using format tag"
+		}
+		return unsupportedReason(t.Elem())
+	case reflect.Slice, reflect.Array:
+		e := t.Elem()
+		switch {
+		case isNamedBasic(e):
+			return "lists of named types can only be read using format tag"
+		case isTLSType(e):
+			return "lists of TLS types are not supported"
+		case isPlainStruct(e) && t.Kind() == reflect.Array:
+			return "arrays of structs are not supported"
+		case isPlainStruct(e):
+			return "lists of structs should have at least one supported field"
+		case e.Kind() == reflect.Slice || e.Kind() == reflect.Array:
+			return "nested lists can only be read using format tag"
+		}
+		return unsupportedReason(e)
+	}
+
+	return fmt.Sprintf("%s values are not supported", t.Kind())
+}
+
+// checkFields reports the exported fields of a struct that cannot be read.
+// The fields of the elements of lists of structs are reported too.
+// An error is always returned for fields with invalid tags (i.e. unknown formats).
+// In strict mode, an error listing all unsupported fields is returned; otherwise, they are only logged.
+func (r *reader) checkFields(vStruct reflect.Value) error {
+	plan := r.plan(vStruct.Type())
+	fields := append([]unsupportedField{}, plan.unsupported...)
+	invalid := append([]string{}, plan.invalid...)
+
+	r.iterateOnFields(vStruct, func(v reflect.Value, p *fieldPlan) {
+		if p.structList {
+			fields = append(fields, elemUnsupported(p.name, v.Type().Elem())...)
+			invalid = append(invalid, elemInvalid(p.name, v.Type().Elem())...)
+		}
+	})
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid tags: %s", strings.Join(invalid, "; "))
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	strs := make([]string, len(fields))
+	for i, f := range fields {
+		r.log(2, "unsupported field skipped", "field", f.name, "type", f.typ, "reason", f.reason)
+		strs[i] = f.String()
+	}
+
+	if !r.strict {
+		return nil
+	}
+
+	return fmt.Errorf("unsupported fields: %s", strings.Join(strs, "; "))
+}
+
+// elemUnsupported returns the fields of the elements of a list of structs that cannot be read.
+func elemUnsupported(list string, t reflect.Type) []unsupportedField {
+	fields := []unsupportedField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || isIgnored(f) {
+			continue
+		}
+
+		name := fmt.Sprintf("%s[].%s", list, f.Name)
+		if isNestedStructList(f.Type) {
+			fields = append(fields, unsupportedField{name, f.Type.String(), "lists of structs cannot be nested"})
+		} else if isUnsupported(f) {
+			fields = append(fields, unsupportedField{name, f.Type.String(), unsupportedReason(f.Type)})
+		}
+	}
+
+	return fields
+}
+
+// elemInvalid returns the fields of the elements of a list of structs with invalid tags.
+func elemInvalid(list string, t reflect.Type) []string {
+	strs := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || isIgnored(f) {
+			continue
+		}
+
+		if format := f.Tag.Get(tagFormat); format != "" && !isFormat(format) {
+			strs = append(strs, fmt.Sprintf("%s[].%s: unknown format: %s", list, f.Name, format))
+		}
+
+		if encoding := f.Tag.Get(tagEncoding); encoding != "" && !isEncoding(encoding) {
+			strs = append(strs, fmt.Sprintf("%s[].%s: unknown encoding: %s", list, f.Name, encoding))
+		}
+
+		if unit := f.Tag.Get(tagUnit); unit != "" && !isUnit(unit) {
+			strs = append(strs, fmt.Sprintf("%s[].%s: unknown unit: %s", list, f.Name, unit))
+		}
+	}
+
+	return strs
+}
+
+// checkNames reports the environment variables with the prefixes for environment variables and file environment variables
+// and the command-line flags that do not belong to any field (i.e. MYAPP_LOGLEVEL instead of MYAPP_LOG_LEVEL).
+// Flags defined using the flag package are known flags too.
+// This check is only done in strict mode.
+func (r *reader) checkNames(vStruct reflect.Value) error {
+	if !r.strict {
+		return nil
+	}
+
+	envNames := []string{}
+	lists := []*fieldPlan{}
+	r.iterateOnFields(vStruct, func(v reflect.Value, p *fieldPlan) {
+		if p.envName != skip && !r.skipEnv {
+			envNames = append(envNames, p.envNames()...)
+		}
+		if p.fileEnvName != skip && !r.skipFileEnv {
+			envNames = append(envNames, p.fileEnvNames()...)
+		}
+		if p.structList {
+			lists = append(lists, p)
+		}
+	})
+
+	prefixes := []string{}
+	if r.prefixEnv != "" && !r.skipEnv {
+		prefixes = append(prefixes, r.prefixEnv)
+	}
+	if r.prefixFileEnv != "" && !r.skipFileEnv {
+		prefixes = append(prefixes, r.prefixFileEnv)
+	}
+
+	unknown := []string{}
+
+	for _, env := range os.Environ() {
+		name := strings.SplitN(env, "=", 2)[0]
+		for _, prefix := range prefixes {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+
+			// The names of elements are only known for the index in the name
+			known := append(r.elemEnvNames(vStruct, lists, name), envNames...)
+			if !contains(known, name) {
+				unknown = append(unknown, "environment variable "+name+suggest(name, known))
+			}
+			break
+		}
+	}
+
+	if !r.skipFlag {
+		flagNames := []string{}
+		flag.VisitAll(func(f *flag.Flag) {
+			flagNames = append(flagNames, f.Name)
+		})
+
+		for name := range parseFlagArgs(os.Args) {
+			if flag.Lookup(name) == nil && name != "h" && name != "help" {
+				unknown = append(unknown, "flag "+name+suggest(name, flagNames))
+			}
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	for _, u := range unknown {
+		r.log(1, "unknown name", "name", u)
+	}
+
+	return fmt.Errorf("unknown names: %s", strings.Join(unknown, "; "))
+}
+
+// elemEnvNames returns the environment variables for the fields of an element of a list of structs
+// if an environment variable has the name of the list and an index (i.e. UPSTREAMS_0_NAME --> UPSTREAMS_0_HOST, UPSTREAMS_0_HOST_FILE, ...).
+func (r *reader) elemEnvNames(vStruct reflect.Value, lists []*fieldPlan, name string) []string {
+	names := []string{}
+	for _, list := range lists {
+		plans := r.elemPlans(vStruct.Field(list.index).Type().Elem())
+
+		for _, prefix := range []string{list.envName, r.fileEnvBase(list)} {
+			i, ok := elemIndex(name, prefix)
+			if !ok {
+				continue
+			}
+
+			for j := range plans {
+				p := r.elemPlan(list, &plans[j], i)
+				if p.envName != skip && !r.skipEnv {
+					names = append(names, p.envName)
+				}
+				if p.fileEnvName != skip && !r.skipFileEnv {
+					names = append(names, p.fileEnvName)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// contains determines whether or not a list of strings has a string.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suggest returns a suggestion for an unknown name using the closest known name if it is close enough.
+func suggest(name string, known []string) string {
+	best, bestDist := "", -1
+	for _, k := range known {
+		if d := editDistance(name, k); bestDist < 0 || d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+
+	// Names with more than a fifth of their characters changed are not similar (short names can have two changes)
+	if bestDist < 0 || bestDist > max(2, len([]rune(name))/5) {
+		return ""
+	}
+
+	return fmt.Sprintf(" (did you mean %s?)", best)
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(t)]
+}
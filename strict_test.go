@@ -0,0 +1,359 @@
+package konfig
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/url"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// level is a named basic type for testing.
+type level int
+
+func TestIsUnsupported(t *testing.T) {
+	type fields struct {
+		sync.Mutex
+		url.URL
+		Labels    map[string]string
+		Formatted map[string]string `format:"json"`
+		Ignored   map[string]string `konfig:"-"`
+		Supported string
+	}
+
+	tests := []struct {
+		field    string
+		expected bool
+	}{
+		{"Mutex", false},
+		{"URL", false},
+		{"Labels", true},
+		{"Formatted", false},
+		{"Ignored", false},
+		{"Supported", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.field, func(t *testing.T) {
+			f, _ := reflect.TypeOf(fields{}).FieldByName(tc.field)
+			assert.Equal(t, tc.expected, isUnsupported(f))
+		})
+	}
+}
+
+func TestUnsupportedReason(t *testing.T) {
+	type server struct {
+		Host string
+	}
+
+	tests := []struct {
+		name           string
+		field          interface{}
+		expectedReason string
+	}{
+		{"Map", map[string]int{}, "maps can only be read using format tag"},
+		{"Struct", server{}, "structs can only be read using format tag"},
+		{"StructPtr", &server{}, "structs can only be read using format tag"},
+		{"Chan", make(chan int), "chan values are not supported"},
+		{"Func", func() {}, "func values are not supported"},
+		{"Interface", []interface{}{}, "interface values are not supported"},
+		{"CertificateSlice", []tls.Certificate{}, "lists of TLS types are not supported"},
+		{"StructArray", [2]server{}, "arrays of structs are not supported"},
+		{"EmptyStructSlice", []struct{}{}, "lists of structs should have at least one supported field"},
+		{"NestedSlice", [][]int{}, "nested lists can only be read using format tag"},
+		{"NestedArray", [2][3]int{}, "nested lists can only be read using format tag"},
+		{"MapSlice", []map[string]int{}, "maps can only be read using format tag"},
+		{"NamedPtr", new(level), "pointers to named types can only be read using format tag"},
+		{"NamedSlice", []level{}, "lists of named types can only be read using format tag"},
+		{"NamedArray", [2]level{}, "lists of named types can only be read using format tag"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedReason, unsupportedReason(reflect.TypeOf(tc.field)))
+		})
+	}
+}
+
+func TestReaderCheckFields(t *testing.T) {
+	type upstream struct {
+		Host     string
+		Meta     map[string]string
+		Children []upstream
+		Ignored  func() `konfig:"-"`
+	}
+
+	type supported struct {
+		sync.Mutex
+		Host    string
+		Labels  map[string]string `format:"yaml"`
+		Handler func()            `konfig:"-"`
+	}
+
+	type unsupported struct {
+		Host      string
+		Labels    map[string]string
+		Handler   func()
+		Level     level
+		Levels    []level
+		Upstreams []upstream
+	}
+
+	tests := []struct {
+		name          string
+		r             *reader
+		s             interface{}
+		expectedError error
+	}{
+		{
+			name:          "Supported",
+			r:             &reader{listSep: ",", strict: true},
+			s:             &supported{},
+			expectedError: nil,
+		},
+		{
+			name:          "NotStrict",
+			r:             &reader{listSep: ","},
+			s:             &unsupported{},
+			expectedError: nil,
+		},
+		{
+			name: "Strict",
+			r:    &reader{listSep: ",", strict: true},
+			s:    &unsupported{},
+			expectedError: errors.New("unsupported fields: " +
+				"Labels (map[string]string): maps can only be read using format tag; " +
+				"Handler (func()): func values are not supported; " +
+				"Levels ([]konfig.level): lists of named types can only be read using format tag; " +
+				"Upstreams[].Meta (map[string]string): maps can only be read using format tag; " +
+				"Upstreams[].Children ([]konfig.upstream): lists of structs cannot be nested"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.r.checkFields(reflect.ValueOf(tc.s).Elem())
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestPickStrict(t *testing.T) {
+	type config struct {
+		StrictHost    string
+		StrictLabels  map[string]string
+		StrictIgnored map[string]string `konfig:"-"`
+	}
+
+	err := os.Setenv("STRICT_HOST", "example.com")
+	assert.NoError(t, err)
+	defer os.Unsetenv("STRICT_HOST")
+
+	t.Run("NotStrict", func(t *testing.T) {
+		c := &config{}
+		err := Pick(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &config{StrictHost: "example.com"}, c)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		c := &config{}
+		err := Pick(c, Strict())
+
+		assert.EqualError(t, err, "unsupported fields: StrictLabels (map[string]string): maps can only be read using format tag")
+		assert.Equal(t, &config{}, c)
+	})
+
+	t.Run("UnknownEnv", func(t *testing.T) {
+		type config struct {
+			LogLevel string
+		}
+
+		err := os.Setenv("UNKNOWN_LOGLEVEL", "debug")
+		assert.NoError(t, err)
+		defer os.Unsetenv("UNKNOWN_LOGLEVEL")
+
+		c := &config{}
+		err = Pick(c, Strict(), PrefixFlag("unknown."), PrefixEnv("UNKNOWN_"))
+
+		assert.EqualError(t, err, "unknown names: environment variable UNKNOWN_LOGLEVEL (did you mean UNKNOWN_LOG_LEVEL?)")
+		assert.Equal(t, &config{}, c)
+	})
+
+	t.Run("NamedTypes", func(t *testing.T) {
+		type config struct {
+			NamedLevel    level
+			NamedLevels   []level
+			NamedLevelPtr *level
+		}
+
+		for _, name := range []string{"NAMED_LEVEL", "NAMED_LEVELS", "NAMED_LEVEL_PTR"} {
+			err := os.Setenv(name, "2")
+			assert.NoError(t, err)
+			defer os.Unsetenv(name)
+		}
+
+		// Pointers to and lists of named types are skipped instead of panicking
+		c := &config{}
+		err := Pick(c)
+		assert.NoError(t, err)
+		assert.Equal(t, &config{NamedLevel: 2}, c)
+
+		err = Pick(&config{}, Strict())
+		assert.EqualError(t, err, "unsupported fields: "+
+			"NamedLevels ([]konfig.level): lists of named types can only be read using format tag; "+
+			"NamedLevelPtr (*konfig.level): pointers to named types can only be read using format tag")
+	})
+
+	t.Run("StrictFromEnv", func(t *testing.T) {
+		err := os.Setenv(envStrict, "true")
+		assert.NoError(t, err)
+		defer os.Unsetenv(envStrict)
+
+		c := &config{}
+		err = Pick(c)
+
+		assert.EqualError(t, err, "unsupported fields: StrictLabels (map[string]string): maps can only be read using format tag")
+	})
+}
+
+func TestReaderCheckNames(t *testing.T) {
+	type upstream struct {
+		Host string
+		Port int
+	}
+
+	type config struct {
+		LogLevel  string
+		Token     string `env:"API_TOKEN" fileenv:"-"`
+		Upstreams []upstream
+	}
+
+	tests := []struct {
+		name          string
+		r             *reader
+		args          []string
+		envs          map[string]string
+		expectedError error
+	}{
+		{
+			name:          "NotStrict",
+			r:             &reader{listSep: ",", prefixFlag: "names.", prefixEnv: "NAMES_", prefixFileEnv: "NAMES_"},
+			args:          []string{"app", "-names.loglevel=debug"},
+			envs:          map[string]string{"NAMES_LOGLEVEL": "debug"},
+			expectedError: nil,
+		},
+		{
+			name: "KnownNames",
+			r:    &reader{listSep: ",", strict: true, prefixFlag: "names.", prefixEnv: "NAMES_", prefixFileEnv: "NAMES_"},
+			args: []string{"app", "-names.log.level=debug", "--names.upstreams.0.host", "localhost"},
+			envs: map[string]string{
+				"NAMES_LOG_LEVEL":             "debug",
+				"NAMES_LOG_LEVEL_FILE":        "/tmp/log_level",
+				"NAMES_UPSTREAMS_1_PORT":      "8080",
+				"NAMES_UPSTREAMS_1_HOST_FILE": "/tmp/host",
+				"API_TOKEN":                   "secret",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "UnknownNames",
+			r:    &reader{listSep: ",", strict: true, prefixFlag: "names.", prefixEnv: "NAMES_", prefixFileEnv: "NAMES_"},
+			args: []string{"app", "-names.loglevel=debug", "-verbose"},
+			envs: map[string]string{
+				"NAMES_LOGLEVEL":         "debug",
+				"NAMES_UPSTREAMS_0_NAME": "a",
+				"NAMES_TOKEN_FILE":       "/tmp/token",
+			},
+			expectedError: errors.New("unknown names: " +
+				"environment variable NAMES_LOGLEVEL (did you mean NAMES_LOG_LEVEL?); " +
+				"environment variable NAMES_TOKEN_FILE; " +
+				"environment variable NAMES_UPSTREAMS_0_NAME (did you mean NAMES_UPSTREAMS_0_HOST?); " +
+				"flag names.loglevel (did you mean names.log.level?); " +
+				"flag verbose"),
+		},
+		{
+			name: "SkippedSources",
+			r:    &reader{listSep: ",", strict: true, skipFlag: true, skipEnv: true, prefixFlag: "names.", prefixEnv: "NAMES_", prefixFileEnv: "NAMES_FILE_"},
+			args: []string{"app", "-names.loglevel=debug"},
+			envs: map[string]string{
+				"NAMES_LOGLEVEL":           "debug",
+				"NAMES_FILE_LOG_LEVL_FILE": "/tmp/log_level",
+			},
+			expectedError: errors.New("unknown names: environment variable NAMES_FILE_LOG_LEVL_FILE (did you mean NAMES_FILE_LOG_LEVEL_FILE?)"),
+		},
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+
+			for name, value := range tc.envs {
+				err := os.Setenv(name, value)
+				assert.NoError(t, err)
+				defer os.Unsetenv(name)
+			}
+
+			v := reflect.ValueOf(&config{}).Elem()
+			tc.r.registerFlags(v)
+
+			err := tc.r.checkNames(v)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	known := []string{"LOG_LEVEL", "LOG_FORMAT", "PORT"}
+
+	tests := []struct {
+		name     string
+		unknown  string
+		expected string
+	}{
+		{"MissingSeparator", "LOGLEVEL", " (did you mean LOG_LEVEL?)"},
+		{"Typo", "LOG_FROMAT", " (did you mean LOG_FORMAT?)"},
+		{"Short", "PROT", " (did you mean PORT?)"},
+		{"NotSimilar", "DATABASE_URL", ""},
+		{"NoKnownName", "", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, suggest(tc.unknown, known))
+		})
+	}
+
+	assert.Equal(t, "", suggest("LOG_LEVEL", nil))
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"kitten", "sitting", 3},
+		{"LOGLEVEL", "LOG_LEVEL", 1},
+		{"héllo", "hello", 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.expected, editDistance(tc.a, tc.b))
+			assert.Equal(t, tc.expected, editDistance(tc.b, tc.a))
+		})
+	}
+}
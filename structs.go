@@ -0,0 +1,382 @@
+package konfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxElems is the maximum number of elements read for a list of structs from indexed names.
+const maxElems = 1000
+
+// isPlainStruct determines whether or not a type is a struct that is not read as a single value (i.e. url.URL).
+func isPlainStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isTypeSupported(t)
+}
+
+// isStructList determines whether or not a type is a list of structs that are read field by field (i.e. []Upstream).
+// The structs should have at least one supported field and lists of structs cannot be nested.
+func isStructList(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || !isPlainStruct(t.Elem()) {
+		return false
+	}
+
+	for i := 0; i < t.Elem().NumField(); i++ {
+		f := t.Elem().Field(i)
+		if f.IsExported() && !isIgnored(f) && !isNestedStructList(f.Type) && isTypeSupported(f.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNestedStructList determines whether or not a field of an element of a list of structs is a list of structs too.
+// This check does not recurse, so recursive types (i.e. a Node with a []Node field) are handled.
+func isNestedStructList(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && isPlainStruct(t.Elem())
+}
+
+// elemPlans returns the plans for the fields of the elements of a list of structs.
+// The names of these fields are not prefixed, since they are always appended to the names of the list field.
+func (r *reader) elemPlans(t reflect.Type) []fieldPlan {
+	er := &reader{
+		listSep:       r.listSep,
+		namingFlag:    r.namingFlag,
+		namingEnv:     r.namingEnv,
+		namingFileEnv: r.namingFileEnv,
+		acronyms:      r.acronyms,
+	}
+
+	plans := []fieldPlan{}
+	for _, p := range er.plan(t).fields {
+		if !isNestedStructList(t.Field(p.index).Type) {
+			plans = append(plans, p)
+		}
+	}
+
+	return plans
+}
+
+// elemPlan returns the plan for a field of the element at index i of a list of structs.
+// The names are joined using the namings of the reader.
+//
+//	Upstreams[0].Host  -->  upstreams.0.host, UPSTREAMS_0_HOST, UPSTREAMS_0_HOST_FILE
+func (r *reader) elemPlan(list, elem *fieldPlan, i int) fieldPlan {
+	p := *elem
+	p.name = fmt.Sprintf("%s[%d].%s", list.name, i, elem.name)
+	p.aliases = aliases{}
+	index := strconv.Itoa(i)
+
+	if list.flagName == skip || elem.flagName == skip {
+		p.flagName = skip
+	} else {
+		p.flagName = joinNames(r.flagNaming(), list.flagName, index, elem.flagName)
+	}
+
+	if list.envName == skip || elem.envName == skip {
+		p.envName = skip
+	} else {
+		p.envName = joinNames(r.envNaming(), list.envName, index, elem.envName)
+	}
+
+	if list.fileEnvName == skip || elem.fileEnvName == skip {
+		p.fileEnvName = skip
+	} else {
+		p.fileEnvName = joinNames(r.fileEnvNaming(), r.fileEnvBase(list), index, elem.fileEnvName)
+	}
+
+	return p
+}
+
+// fileEnvBase returns the name of the file environment variable for a list of structs without its suffix (i.e. UPSTREAMS_FILE --> UPSTREAMS).
+func (r *reader) fileEnvBase(list *fieldPlan) string {
+	return strings.TrimSuffix(list.fileEnvName, fileSuffix(r.fileEnvNaming()))
+}
+
+// elemValue is a value read for a field of an element of a list of structs.
+type elemValue struct {
+	index  int
+	plan   fieldPlan
+	val    string
+	source Source
+	path   string
+}
+
+// name returns the name of the flag or environment variable that an element value is read from.
+func (ev elemValue) name() string {
+	if ev.source == SourceFlag {
+		return ev.plan.flagName
+	}
+	return ev.plan.envName
+}
+
+// readElems reads the values for the fields of the elements of a list of structs from indexed names.
+// Elements are read from index 0 until an index with no value for any of its fields.
+// Fields read from files that do not exist yet are returned without values, so the files can be watched.
+func (r *reader) readElems(list *fieldPlan, t reflect.Type) []elemValue {
+	plans := r.elemPlans(t)
+
+	vals := []elemValue{}
+	for i := 0; i < maxElems; i++ {
+		found := false
+		for j := range plans {
+			p := r.elemPlan(list, &plans[j], i)
+			val, source, path, _ := r.getFieldValue(&p)
+
+			// A file may not exist yet, but the element still exists
+			if val != "" || path != "" {
+				found = true
+				vals = append(vals, elemValue{i, p, val, source, path})
+			}
+		}
+
+		if !found {
+			return vals
+		}
+	}
+
+	return vals
+}
+
+// hasElemValues determines whether or not any field of any element of a list of structs has a value.
+func hasElemValues(elems []elemValue) bool {
+	for _, ev := range elems {
+		if ev.val != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// defineElemFlags defines the flags for the elements of a list of structs that are set in command-line arguments,
+// so flag.Parse() can be called.
+func (r *reader) defineElemFlags(list *fieldPlan, t reflect.Type) {
+	if list.flagName == skip {
+		return
+	}
+
+	plans := r.elemPlans(t)
+
+	for name := range parseFlagArgs(os.Args) {
+		i, ok := elemIndex(name, list.flagName)
+		if !ok {
+			continue
+		}
+
+		for j := range plans {
+			if p := r.elemPlan(list, &plans[j], i); p.flagName == name {
+				r.defineFlag(&p, reflect.Zero(t.Field(p.index).Type).Interface())
+			}
+		}
+	}
+}
+
+// setStructList sets a new value for a list of structs.
+// The value is either a JSON array of objects or, if the list is read from indexed names, the values of its elements.
+func (r *reader) setStructList(f fieldInfo, val string) (bool, error) {
+	var s reflect.Value
+	var err error
+
+	if f.indexed {
+		s, err = r.buildIndexedList(f)
+	} else {
+		s, err = r.buildJSONList(f, val)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if reflect.DeepEqual(f.value.Interface(), s.Interface()) {
+		return false, nil
+	}
+
+	r.log(5, "setting struct slice", "field", f.name, "value", s.Interface())
+	f.value.Set(s)
+	r.notifySubscribers(f.name, s.Interface())
+
+	return true, nil
+}
+
+// elemReader returns a reader for setting the fields of elements, so subscribers are notified only once for the whole list.
+func (r *reader) elemReader() *reader {
+	return &reader{
+		debug:  r.debug,
+		logger: r.logger,
+	}
+}
+
+// buildIndexedList creates a new list of structs from the values read from indexed names.
+// If the values are not already read, they are read again.
+// Elements with no values (i.e. only their files are set and do not exist yet) are skipped.
+// If no element has a value, the list keeps its current value.
+func (r *reader) buildIndexedList(f fieldInfo) (reflect.Value, error) {
+	t := f.value.Type()
+
+	vals := f.elems
+	if vals == nil {
+		vals = r.readElems(f.list, t.Elem())
+	}
+
+	// The positions of elements in the new list
+	positions := map[int]int{}
+	for _, ev := range vals {
+		if _, ok := positions[ev.index]; !ok && ev.val != "" {
+			positions[ev.index] = len(positions)
+		}
+	}
+
+	if len(positions) == 0 {
+		return f.value, nil
+	}
+
+	s := reflect.MakeSlice(t, len(positions), len(positions))
+	er := r.elemReader()
+
+	for _, ev := range vals {
+		val := ev.val
+		if val == "" {
+			continue
+		}
+
+		if ev.source == SourceFile || ev.plan.binary {
+			er.markSecret(ev.plan.name)
+		}
+
+		if r.expand && !ev.plan.binary {
+			var err error
+			if val, err = r.expandValue(ev.plan.name, val); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+
+		ef := fieldInfo{
+			value:    s.Index(positions[ev.index]).Field(ev.plan.index),
+			name:     ev.plan.name,
+			listSep:  ev.plan.listSep,
+			encoding: ev.plan.encoding,
+			layout:   ev.plan.layout,
+			unit:     ev.plan.unit,
+			binary:   ev.plan.binary,
+		}
+
+		// Binary values are read from files as they are and are base64-encoded in flags and environment variables
+		if ef.binary && ev.source != SourceFile && ef.encoding == "" {
+			ef.encoding = "base64"
+		}
+
+		if _, err := er.setFieldValue(ef, val); err != nil {
+			return reflect.Value{}, fmt.Errorf("%s from %s: %s", ev.plan.name, describeSource(ev.source, ev.name(), ev.path), err)
+		}
+	}
+
+	return s, nil
+}
+
+// buildJSONList parses a JSON array of objects and creates a new list of structs.
+// The keys of objects are matched against json tags or the names of fields (case-insensitive).
+// The values of fields are read the same as other values (i.e. "512KiB" for a konfig.ByteSize field).
+func (r *reader) buildJSONList(f fieldInfo, val string) (reflect.Value, error) {
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(val), &items); err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid JSON value: %s", err)
+	}
+
+	t := f.value.Type()
+	plans := r.elemPlans(t.Elem())
+
+	s := reflect.MakeSlice(t, len(items), len(items))
+	er := r.elemReader()
+
+	for i, item := range items {
+		for key, raw := range item {
+			p := jsonFieldPlan(t.Elem(), plans, key)
+			if p == nil {
+				return reflect.Value{}, fmt.Errorf("unknown field in %s[%d]: %s", f.name, i, key)
+			}
+
+			ev, err := jsonValue(raw, p.listSep)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid value for %s[%d].%s: %s", f.name, i, p.name, err)
+			}
+
+			if ev == "" {
+				continue
+			}
+
+			ef := fieldInfo{
+				value:    s.Index(i).Field(p.index),
+				name:     fmt.Sprintf("%s[%d].%s", f.name, i, p.name),
+				listSep:  p.listSep,
+				encoding: p.encoding,
+				layout:   p.layout,
+				unit:     p.unit,
+				binary:   p.binary,
+			}
+
+			// Binary values are base64-encoded in JSON
+			if ef.binary && ef.encoding == "" {
+				ef.encoding = "base64"
+			}
+
+			if _, err := er.setFieldValue(ef, ev); err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid value for %s: %s", ef.name, err)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// jsonFieldPlan finds the plan for the field of a struct that a JSON key refers to.
+func jsonFieldPlan(t reflect.Type, plans []fieldPlan, key string) *fieldPlan {
+	for i := range plans {
+		tag := strings.Split(t.Field(plans[i].index).Tag.Get("json"), ",")[0]
+		if tag == key || (tag == "" && strings.EqualFold(plans[i].name, key)) {
+			return &plans[i]
+		}
+	}
+
+	return nil
+}
+
+// jsonValue returns the string value of a JSON value, so it can be set on a field.
+// Arrays are joined using the list separator of the field.
+func jsonValue(raw json.RawMessage, listSep string) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []interface{}:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return "", err
+		}
+
+		vals := make([]string, len(items))
+		for i, item := range items {
+			var err error
+			if vals[i], err = jsonValue(item, listSep); err != nil {
+				return "", err
+			}
+		}
+		return strings.Join(vals, listSep), nil
+	case map[string]interface{}:
+		return "", errors.New("objects are not supported")
+	default:
+		// Numbers and booleans are used as they are
+		return string(raw), nil
+	}
+}
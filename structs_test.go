@@ -0,0 +1,606 @@
+package konfig
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type upstream struct {
+	Host   string
+	Port   int
+	Weight float64 `json:"w"`
+	Tags   []string
+	Limit  ByteSize
+	Token  []byte
+}
+
+func TestIsStructList(t *testing.T) {
+	type node struct {
+		Name     string
+		Children []node
+	}
+
+	type unsupported struct {
+		Values map[string]string
+	}
+
+	type nested struct {
+		Name      string
+		Upstreams []upstream
+	}
+
+	tests := []struct {
+		name           string
+		typ            reflect.Type
+		expectedResult bool
+	}{
+		{"StructList", reflect.TypeOf([]upstream{}), true},
+		{"RecursiveStruct", reflect.TypeOf([]node{}), true},
+		{"NestedStructList", reflect.TypeOf([]nested{}), true},
+		{"NoSupportedField", reflect.TypeOf([]unsupported{}), false},
+		{"Struct", reflect.TypeOf(upstream{}), false},
+		{"PointerList", reflect.TypeOf([]*upstream{}), false},
+		{"URLList", reflect.TypeOf([]url.URL{}), false},
+		{"StringList", reflect.TypeOf([]string{}), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, isStructList(tc.typ))
+		})
+	}
+}
+
+func TestElemPlans(t *testing.T) {
+	type nested struct {
+		Name      string
+		Upstreams []upstream
+	}
+
+	r := &reader{listSep: ","}
+	plans := r.elemPlans(reflect.TypeOf(nested{}))
+
+	assert.Len(t, plans, 1)
+	assert.Equal(t, "Name", plans[0].name)
+}
+
+func TestElemPlan(t *testing.T) {
+	tests := []struct {
+		name         string
+		r            *reader
+		list         fieldPlan
+		elem         fieldPlan
+		i            int
+		expectedPlan fieldPlan
+	}{
+		{
+			name:         "Default",
+			r:            &reader{},
+			list:         fieldPlan{name: "Upstreams", flagName: "upstreams", envName: "UPSTREAMS", fileEnvName: "UPSTREAMS_FILE"},
+			elem:         fieldPlan{index: 1, name: "Host", flagName: "host", envName: "HOST", fileEnvName: "HOST_FILE"},
+			i:            2,
+			expectedPlan: fieldPlan{index: 1, name: "Upstreams[2].Host", flagName: "upstreams.2.host", envName: "UPSTREAMS_2_HOST", fileEnvName: "UPSTREAMS_2_HOST_FILE"},
+		},
+		{
+			name:         "CustomNames",
+			r:            &reader{},
+			list:         fieldPlan{name: "Upstreams", flagName: "backends", envName: "BACKENDS", fileEnvName: "BACKENDS_PATH"},
+			elem:         fieldPlan{name: "Host", flagName: "hostname", envName: "HOSTNAME", fileEnvName: "HOSTNAME_PATH"},
+			i:            0,
+			expectedPlan: fieldPlan{name: "Upstreams[0].Host", flagName: "backends.0.hostname", envName: "BACKENDS_0_HOSTNAME", fileEnvName: "BACKENDS_PATH_0_HOSTNAME_PATH"},
+		},
+		{
+			name:         "SkippedList",
+			r:            &reader{},
+			list:         fieldPlan{name: "Upstreams", flagName: "-", envName: "-", fileEnvName: "-"},
+			elem:         fieldPlan{name: "Host", flagName: "host", envName: "HOST", fileEnvName: "HOST_FILE"},
+			i:            0,
+			expectedPlan: fieldPlan{name: "Upstreams[0].Host", flagName: "-", envName: "-", fileEnvName: "-"},
+		},
+		{
+			name:         "SkippedElem",
+			r:            &reader{},
+			list:         fieldPlan{name: "Upstreams", flagName: "upstreams", envName: "UPSTREAMS", fileEnvName: "UPSTREAMS_FILE"},
+			elem:         fieldPlan{name: "Host", flagName: "-", envName: "-", fileEnvName: "-"},
+			i:            0,
+			expectedPlan: fieldPlan{name: "Upstreams[0].Host", flagName: "-", envName: "-", fileEnvName: "-"},
+		},
+		{
+			name:         "KebabCase",
+			r:            &reader{namingFlag: KebabCase, namingEnv: KebabCase},
+			list:         fieldPlan{name: "Upstreams", flagName: "upstreams", envName: "upstreams", fileEnvName: "upstreams-file"},
+			elem:         fieldPlan{name: "Host", flagName: "host", envName: "host", fileEnvName: "host-file"},
+			i:            1,
+			expectedPlan: fieldPlan{name: "Upstreams[1].Host", flagName: "upstreams-1-host", envName: "upstreams-1-host", fileEnvName: "upstreams-1-host-file"},
+		},
+		{
+			name:         "CamelCase",
+			r:            &reader{namingFlag: CamelCase, namingFileEnv: CamelCase},
+			list:         fieldPlan{name: "Upstreams", flagName: "upstreams", envName: "UPSTREAMS", fileEnvName: "upstreamsFile"},
+			elem:         fieldPlan{name: "Host", flagName: "host", envName: "HOST", fileEnvName: "hostFile"},
+			i:            0,
+			expectedPlan: fieldPlan{name: "Upstreams[0].Host", flagName: "upstreams0Host", envName: "UPSTREAMS_0_HOST", fileEnvName: "upstreams0HostFile"},
+		},
+		{
+			name: "NamingFunc",
+			r: &reader{
+				namingFlag: NamingFunc(func(words []string) string {
+					return strings.ToLower(strings.Join(words, "/"))
+				}),
+			},
+			list:         fieldPlan{name: "Upstreams", flagName: "upstreams", envName: "UPSTREAMS", fileEnvName: "UPSTREAMS_FILE"},
+			elem:         fieldPlan{name: "Host", flagName: "host", envName: "HOST", fileEnvName: "HOST_FILE"},
+			i:            3,
+			expectedPlan: fieldPlan{name: "Upstreams[3].Host", flagName: "upstreams/3/host", envName: "UPSTREAMS_3_HOST", fileEnvName: "UPSTREAMS_3_HOST_FILE"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPlan, tc.r.elemPlan(&tc.list, &tc.elem, tc.i))
+		})
+	}
+}
+
+func TestReaderReadElems(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-upstreams.1.port=8080"}
+
+	err := os.Setenv("UPSTREAMS_0_HOST", "a.example.com")
+	assert.NoError(t, err)
+	defer os.Unsetenv("UPSTREAMS_0_HOST")
+
+	err = os.Setenv("UPSTREAMS_1_HOST_FILE", "/path/to/missing")
+	assert.NoError(t, err)
+	defer os.Unsetenv("UPSTREAMS_1_HOST_FILE")
+
+	// Elements after a gap are not read
+	err = os.Setenv("UPSTREAMS_3_HOST", "d.example.com")
+	assert.NoError(t, err)
+	defer os.Unsetenv("UPSTREAMS_3_HOST")
+
+	r := &reader{listSep: ","}
+	list := r.newFieldPlan("Upstreams", "")
+	vals := r.readElems(&list, reflect.TypeOf(upstream{}))
+
+	assert.Len(t, vals, 3)
+
+	assert.Equal(t, 0, vals[0].index)
+	assert.Equal(t, "Upstreams[0].Host", vals[0].plan.name)
+	assert.Equal(t, "a.example.com", vals[0].val)
+	assert.Equal(t, SourceEnv, vals[0].source)
+
+	assert.Equal(t, 1, vals[1].index)
+	assert.Equal(t, "Upstreams[1].Host", vals[1].plan.name)
+	assert.Equal(t, "", vals[1].val)
+	assert.Equal(t, "/path/to/missing", vals[1].path)
+
+	assert.Equal(t, 1, vals[2].index)
+	assert.Equal(t, "Upstreams[1].Port", vals[2].plan.name)
+	assert.Equal(t, "8080", vals[2].val)
+	assert.Equal(t, SourceFlag, vals[2].source)
+}
+
+func TestReaderDefineElemFlags(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-elem.flags.0.host=a", "--elem.flags.12.port", "80", "-elem.flags.x.host=b", "-elem.flags.0.unknown=c"}
+
+	r := &reader{listSep: ","}
+	list := r.newFieldPlan("ElemFlags", "")
+	r.defineElemFlags(&list, reflect.TypeOf(upstream{}))
+
+	assert.NotNil(t, flag.Lookup("elem.flags.0.host"))
+	assert.NotNil(t, flag.Lookup("elem.flags.12.port"))
+	assert.Nil(t, flag.Lookup("elem.flags.x.host"))
+	assert.Nil(t, flag.Lookup("elem.flags.0.unknown"))
+}
+
+func TestJSONValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		expectedValue string
+		expectedError error
+	}{
+		{"String", `"a.example.com"`, "a.example.com", nil},
+		{"Number", `1.5`, "1.5", nil},
+		{"Boolean", `true`, "true", nil},
+		{"Null", `null`, "", nil},
+		{"Array", `["x", 1, true]`, "x|1|true", nil},
+		{"Object", `{"a": 1}`, "", errors.New("objects are not supported")},
+		{"NestedObject", `[{"a": 1}]`, "", errors.New("objects are not supported")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := jsonValue([]byte(tc.raw), "|")
+
+			assert.Equal(t, tc.expectedValue, val)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestReaderSetStructList(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         []upstream
+		val             string
+		expectedUpdated bool
+		expectedError   string
+		expectedResult  []upstream
+	}{
+		{
+			name:            "JSON",
+			val:             `[{"host": "a.example.com", "PORT": 8080, "w": 0.5, "tags": ["x", "y"], "limit": "1KiB", "token": "AAE="}, {"host": "b.example.com"}]`,
+			expectedUpdated: true,
+			expectedResult: []upstream{
+				{Host: "a.example.com", Port: 8080, Weight: 0.5, Tags: []string{"x", "y"}, Limit: KiB, Token: []byte{0, 1}},
+				{Host: "b.example.com"},
+			},
+		},
+		{
+			name:            "Empty",
+			current:         []upstream{{Host: "a.example.com"}},
+			val:             `[]`,
+			expectedUpdated: true,
+			expectedResult:  []upstream{},
+		},
+		{
+			name:            "NoChange",
+			current:         []upstream{{Host: "a.example.com"}},
+			val:             `[{"host": "a.example.com"}]`,
+			expectedUpdated: false,
+			expectedResult:  []upstream{{Host: "a.example.com"}},
+		},
+		{
+			name:           "InvalidJSON",
+			val:            `[{"host": `,
+			expectedError:  "invalid JSON value: unexpected end of JSON input",
+			expectedResult: nil,
+		},
+		{
+			name:           "UnknownField",
+			val:            `[{"weight": 0.5}]`,
+			expectedError:  "unknown field in Upstreams[0]: weight",
+			expectedResult: nil,
+		},
+		{
+			name:           "InvalidFieldValue",
+			val:            `[{"port": {"a": 1}}]`,
+			expectedError:  "invalid value for Upstreams[0].Port: objects are not supported",
+			expectedResult: nil,
+		},
+		{
+			name:           "InvalidValue",
+			val:            `[{"port": "invalid"}]`,
+			expectedError:  `invalid value for Upstreams[0].Port: strconv.ParseInt: parsing "invalid": invalid syntax`,
+			expectedResult: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := struct{ Upstreams []upstream }{tc.current}
+
+			r := &reader{listSep: ","}
+			p := r.newFieldPlan("Upstreams", "")
+			f := fieldInfo{
+				value:   reflect.ValueOf(&s).Elem().Field(0),
+				name:    "Upstreams",
+				listSep: ",",
+				list:    &p,
+			}
+
+			updated, err := r.setFieldValue(f, tc.val)
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResult, s.Upstreams)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Equal(t, tc.current, s.Upstreams)
+			}
+		})
+	}
+}
+
+func TestPickStructList(t *testing.T) {
+	type config struct {
+		Name      string
+		Upstreams []upstream
+	}
+
+	type env struct {
+		varName string
+		value   string
+	}
+
+	tests := []struct {
+		name              string
+		args              []string
+		envs              []env
+		opts              []Option
+		expectedError     string
+		expectedUpstreams []upstream
+		expectedSource    Source
+	}{
+		{
+			name:              "NoValue",
+			args:              []string{"app"},
+			expectedUpstreams: []upstream{{Host: "default.example.com"}},
+			expectedSource:    SourceDefault,
+		},
+		{
+			name: "IndexedNames",
+			args: []string{"app", "-upstreams.1.host=b.example.com"},
+			envs: []env{
+				{"UPSTREAMS_0_HOST", "a.example.com"},
+				{"UPSTREAMS_0_PORT", "8080"},
+				{"UPSTREAMS_0_TAGS", "x,y"},
+				{"UPSTREAMS_1_LIMIT", "1MiB"},
+				{"UPSTREAMS_1_TOKEN", "AAE="},
+			},
+			expectedUpstreams: []upstream{
+				{Host: "a.example.com", Port: 8080, Tags: []string{"x", "y"}},
+				{Host: "b.example.com", Limit: MiB, Token: []byte{0, 1}},
+			},
+			expectedSource: SourceEnv,
+		},
+		{
+			name: "MissingFiles",
+			args: []string{"app"},
+			envs: []env{
+				{"UPSTREAMS_0_HOST_FILE", "/path/to/missing"},
+			},
+			expectedUpstreams: []upstream{{Host: "default.example.com"}},
+			expectedSource:    SourceDefault,
+		},
+		{
+			name: "ElementWithMissingFile",
+			args: []string{"app"},
+			envs: []env{
+				{"UPSTREAMS_0_HOST_FILE", "/path/to/missing"},
+				{"UPSTREAMS_1_HOST", "b.example.com"},
+			},
+			expectedUpstreams: []upstream{{Host: "b.example.com"}},
+			expectedSource:    SourceEnv,
+		},
+		{
+			name: "JSON",
+			args: []string{"app"},
+			envs: []env{
+				{"UPSTREAMS", `[{"host": "c.example.com", "port": 443}]`},
+				{"UPSTREAMS_0_HOST", "a.example.com"},
+			},
+			expectedUpstreams: []upstream{{Host: "c.example.com", Port: 443}},
+			expectedSource:    SourceEnv,
+		},
+		{
+			name: "InvalidValue",
+			args: []string{"app"},
+			envs: []env{
+				{"UPSTREAMS_0_HOST", "a.example.com"},
+				{"UPSTREAMS_0_PORT", "invalid"},
+			},
+			expectedError:     `invalid values: Upstreams[0].Port from environment variable UPSTREAMS_0_PORT: strconv.ParseInt: parsing "invalid": invalid syntax`,
+			expectedUpstreams: []upstream{{Host: "default.example.com"}},
+			expectedSource:    SourceEnv,
+		},
+		{
+			name: "Expand",
+			args: []string{"app", "-name=example.com"},
+			envs: []env{
+				{"UPSTREAMS_0_HOST", "a.${.Name}"},
+			},
+			opts:              []Option{Expand()},
+			expectedUpstreams: []upstream{{Host: "a.example.com"}},
+			expectedSource:    SourceEnv,
+		},
+		{
+			name: "WithOptions",
+			args: []string{"app"},
+			envs: []env{
+				{"APP_UPSTREAMS_0_TAGS", "x;y"},
+			},
+			opts:              []Option{PrefixEnv("APP_"), ListSep(";")},
+			expectedUpstreams: []upstream{{Tags: []string{"x", "y"}}},
+			expectedSource:    SourceEnv,
+		},
+	}
+
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+
+			for _, e := range tc.envs {
+				err := os.Setenv(e.varName, e.value)
+				assert.NoError(t, err)
+				defer os.Unsetenv(e.varName)
+			}
+
+			c := &config{
+				Upstreams: []upstream{{Host: "default.example.com"}},
+			}
+
+			l := NewLoader(tc.opts...)
+			err := l.Pick(c)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+			assert.Equal(t, tc.expectedUpstreams, c.Upstreams)
+			assert.Equal(t, tc.expectedSource, l.Describe()[1].Source)
+		})
+	}
+}
+
+func TestWatchStructList(t *testing.T) {
+	type config struct {
+		sync.Mutex
+		WatchUpstreams []upstream
+	}
+
+	dir, err := ioutil.TempDir("", "gotest_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "host")
+	err = ioutil.WriteFile(path, []byte("a.example.com\n"), 0644)
+	assert.NoError(t, err)
+
+	err = os.Setenv("WATCH_UPSTREAMS_0_HOST_FILE", path)
+	assert.NoError(t, err)
+	defer os.Unsetenv("WATCH_UPSTREAMS_0_HOST_FILE")
+
+	err = os.Setenv("WATCH_UPSTREAMS_0_PORT", "8080")
+	assert.NoError(t, err)
+	defer os.Unsetenv("WATCH_UPSTREAMS_0_PORT")
+
+	c := &config{}
+	ch := make(chan Update, 10)
+	close, err := Watch(c, []chan Update{ch}, Polling(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer close()
+
+	// The initial value
+	update := <-ch
+	assert.Equal(t, "WatchUpstreams", update.Name)
+
+	c.Lock()
+	assert.Equal(t, []upstream{{Host: "a.example.com", Port: 8080}}, c.WatchUpstreams)
+	c.Unlock()
+
+	err = ioutil.WriteFile(path, []byte("b.example.com\n"), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case update := <-ch:
+		assert.Equal(t, "WatchUpstreams", update.Name)
+		assert.Equal(t, []upstream{{Host: "b.example.com", Port: 8080}}, update.Value)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for the list to be rebuilt")
+	}
+
+	c.Lock()
+	assert.Equal(t, []upstream{{Host: "b.example.com", Port: 8080}}, c.WatchUpstreams)
+	c.Unlock()
+}
+
+func TestWatchStructListMissingFile(t *testing.T) {
+	type config struct {
+		sync.Mutex
+		WatchBackends []upstream
+	}
+
+	dir, err := ioutil.TempDir("", "gotest_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "host")
+	err = os.Setenv("WATCH_BACKENDS_0_HOST_FILE", path)
+	assert.NoError(t, err)
+	defer os.Unsetenv("WATCH_BACKENDS_0_HOST_FILE")
+
+	c := &config{
+		WatchBackends: []upstream{{Host: "default.example.com"}},
+	}
+
+	ch := make(chan Update, 10)
+	close, err := Watch(c, []chan Update{ch}, Polling(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer close()
+
+	// The default value is kept until the file is created
+	c.Lock()
+	assert.Equal(t, []upstream{{Host: "default.example.com"}}, c.WatchBackends)
+	c.Unlock()
+
+	err = ioutil.WriteFile(path, []byte("a.example.com\n"), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case update := <-ch:
+		assert.Equal(t, "WatchBackends", update.Name)
+		assert.Equal(t, []upstream{{Host: "a.example.com"}}, update.Value)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for the list to be rebuilt")
+	}
+}
+
+func TestLookupStructs(t *testing.T) {
+	origArgs := os.Args
+	defer func() {
+		os.Args = origArgs
+	}()
+	os.Args = []string{"app", "-lookup.upstreams.0.port=8080"}
+
+	err := os.Setenv("LOOKUP_UPSTREAMS_0_HOST", "a.example.com")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_UPSTREAMS_0_HOST")
+
+	err = os.Setenv("LOOKUP_BACKENDS", `[{"host": "b.example.com"}]`)
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_BACKENDS")
+
+	err = os.Setenv("LOOKUP_MISSING_0_HOST_FILE", "/path/to/missing")
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_MISSING_0_HOST_FILE")
+
+	err = os.Setenv("LOOKUP_INVALID", `[{"host": `)
+	assert.NoError(t, err)
+	defer os.Unsetenv("LOOKUP_INVALID")
+
+	upstreams := []upstream{}
+	backends := []upstream{}
+	missing := []upstream{{Host: "default.example.com"}}
+	invalid := []upstream{{Host: "default.example.com"}}
+
+	l := NewLookup()
+	kUpstreams := l.RegisterFlag("LookupUpstreams", "", "[]konfig.upstream", upstreams)
+	kBackends := l.RegisterFlag("LookupBackends", "", "[]konfig.upstream", backends)
+	kMissing := l.RegisterFlag("LookupMissing", "", "[]konfig.upstream", missing)
+	kInvalid := l.RegisterFlag("LookupInvalid", "", "[]konfig.upstream", invalid)
+
+	assert.NotNil(t, flag.Lookup("lookup.upstreams"))
+	assert.NotNil(t, flag.Lookup("lookup.upstreams.0.port"))
+
+	l.Structs(kUpstreams, &upstreams)
+	assert.Equal(t, []upstream{{Host: "a.example.com", Port: 8080}}, upstreams)
+
+	l.Structs(kBackends, &backends)
+	assert.Equal(t, []upstream{{Host: "b.example.com"}}, backends)
+
+	l.Structs(kMissing, &missing)
+	assert.Equal(t, []upstream{{Host: "default.example.com"}}, missing)
+
+	l.Structs(kInvalid, &invalid)
+	assert.Equal(t, []upstream{{Host: "default.example.com"}}, invalid)
+
+	assert.EqualError(t, l.Err(), "invalid values: LookupInvalid from environment variable LOOKUP_INVALID: invalid JSON value: unexpected end of JSON input")
+}
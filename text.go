@@ -0,0 +1,69 @@
+package konfig
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"time"
+)
+
+// textType is a type that its values are parsed from strings (i.e. net.IP and netip.Addr).
+// Fields of these types are supported in scalar, pointer, and slice forms.
+// layout is the value of layout tag and is only used by types that can be formatted differently (i.e. time.Time).
+type textType struct {
+	name  string
+	parse func(s, layout string) (interface{}, error)
+}
+
+// textTypes are the supported types that are parsed from strings.
+var textTypes = map[reflect.Type]textType{
+	reflect.TypeOf(net.IP{}): {"ip", func(s, _ string) (interface{}, error) {
+		return ParseIP(s)
+	}},
+	reflect.TypeOf(net.IPNet{}): {"ipnet", func(s, _ string) (interface{}, error) {
+		n, err := ParseIPNet(s)
+		if err != nil {
+			return nil, err
+		}
+		return *n, nil
+	}},
+	reflect.TypeOf(netip.Addr{}): {"addr", func(s, _ string) (interface{}, error) {
+		return netip.ParseAddr(s)
+	}},
+	reflect.TypeOf(netip.Prefix{}): {"prefix", func(s, _ string) (interface{}, error) {
+		return netip.ParsePrefix(s)
+	}},
+	reflect.TypeOf(netip.AddrPort{}): {"addrport", func(s, _ string) (interface{}, error) {
+		return netip.ParseAddrPort(s)
+	}},
+	reflect.TypeOf(time.Time{}): {"time", func(s, layout string) (interface{}, error) {
+		return ParseTime(s, layout)
+	}},
+	reflect.TypeOf(&time.Location{}): {"location", func(s, _ string) (interface{}, error) {
+		return time.LoadLocation(s)
+	}},
+	reflect.TypeOf(TimeOfDay{}): {"time of day", func(s, _ string) (interface{}, error) {
+		return ParseTimeOfDay(s)
+	}},
+	reflect.TypeOf(ByteSize(0)): {"byte size", func(s, _ string) (interface{}, error) {
+		return ParseByteSize(s)
+	}},
+	reflect.TypeOf(Quantity(0)): {"quantity", func(s, _ string) (interface{}, error) {
+		return ParseQuantity(s)
+	}},
+}
+
+// lookupTextType returns the text type for a field type either directly or through a pointer or a slice.
+func lookupTextType(t reflect.Type) (textType, reflect.Kind, bool) {
+	if tt, ok := textTypes[t]; ok {
+		return tt, reflect.Invalid, true
+	}
+
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		if tt, ok := textTypes[t.Elem()]; ok {
+			return tt, t.Kind(), true
+		}
+	}
+
+	return textType{}, reflect.Invalid, false
+}
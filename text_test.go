@@ -0,0 +1,169 @@
+package konfig
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupTextType(t *testing.T) {
+	tests := []struct {
+		name         string
+		typ          reflect.Type
+		expectedName string
+		expectedKind reflect.Kind
+		expectedOK   bool
+	}{
+		{"IP", reflect.TypeOf(net.IP{}), "ip", reflect.Invalid, true},
+		{"IPPtr", reflect.TypeOf(&net.IP{}), "ip", reflect.Ptr, true},
+		{"IPSlice", reflect.TypeOf([]net.IP{}), "ip", reflect.Slice, true},
+		{"IPNetPtr", reflect.TypeOf(&net.IPNet{}), "ipnet", reflect.Ptr, true},
+		{"Prefix", reflect.TypeOf(netip.Prefix{}), "prefix", reflect.Invalid, true},
+		{"AddrPortSlice", reflect.TypeOf([]netip.AddrPort{}), "addrport", reflect.Slice, true},
+		{"Time", reflect.TypeOf(time.Time{}), "time", reflect.Invalid, true},
+		{"Location", reflect.TypeOf(time.UTC), "location", reflect.Invalid, true},
+		{"LocationSlice", reflect.TypeOf([]*time.Location{}), "location", reflect.Slice, true},
+		{"TimeOfDayPtr", reflect.TypeOf(&TimeOfDay{}), "time of day", reflect.Ptr, true},
+		{"Bytes", reflect.TypeOf([]byte{}), "", reflect.Invalid, false},
+		{"String", reflect.TypeOf(""), "", reflect.Invalid, false},
+		{"SliceOfPointers", reflect.TypeOf([]*netip.Addr{}), "", reflect.Invalid, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt, kind, ok := lookupTextType(tc.typ)
+
+			assert.Equal(t, tc.expectedName, tt.name)
+			assert.Equal(t, tc.expectedKind, kind)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestReaderSetText(t *testing.T) {
+	type fields struct {
+		IP            net.IP
+		IPPtr         *net.IP
+		IPSlice       []net.IP
+		IPNet         net.IPNet
+		IPNetPtr      *net.IPNet
+		IPNetSlice    []net.IPNet
+		Addr          netip.Addr
+		AddrPtr       *netip.Addr
+		AddrSlice     []netip.Addr
+		Prefix        netip.Prefix
+		PrefixPtr     *netip.Prefix
+		PrefixSlice   []netip.Prefix
+		AddrPort      netip.AddrPort
+		AddrPortPtr   *netip.AddrPort
+		AddrPortSlice []netip.AddrPort
+	}
+
+	ip := net.ParseIP("10.0.0.1")
+	_, ipNet, _ := net.ParseCIDR("10.0.0.0/8")
+	addr := netip.MustParseAddr("fd00::1")
+	prefix := netip.MustParsePrefix("fd00::/8")
+	addrPort := netip.MustParseAddrPort("[::1]:443")
+
+	values := map[string]string{
+		"IP":            "10.0.0.1",
+		"IPPtr":         "10.0.0.1",
+		"IPSlice":       "10.0.0.1,10.0.0.1",
+		"IPNet":         "10.0.0.0/8",
+		"IPNetPtr":      "10.0.0.0/8",
+		"IPNetSlice":    "10.0.0.0/8",
+		"Addr":          "fd00::1",
+		"AddrPtr":       "fd00::1",
+		"AddrSlice":     "fd00::1,fd00::1",
+		"Prefix":        "fd00::/8",
+		"PrefixPtr":     "fd00::/8",
+		"PrefixSlice":   "fd00::/8",
+		"AddrPort":      "[::1]:443",
+		"AddrPortPtr":   "[::1]:443",
+		"AddrPortSlice": "[::1]:443",
+	}
+
+	expected := fields{
+		IP:            ip,
+		IPPtr:         &ip,
+		IPSlice:       []net.IP{ip, ip},
+		IPNet:         *ipNet,
+		IPNetPtr:      ipNet,
+		IPNetSlice:    []net.IPNet{*ipNet},
+		Addr:          addr,
+		AddrPtr:       &addr,
+		AddrSlice:     []netip.Addr{addr, addr},
+		Prefix:        prefix,
+		PrefixPtr:     &prefix,
+		PrefixSlice:   []netip.Prefix{prefix},
+		AddrPort:      addrPort,
+		AddrPortPtr:   &addrPort,
+		AddrPortSlice: []netip.AddrPort{addrPort},
+	}
+
+	tests := []struct {
+		name            string
+		s               fields
+		values          map[string]string
+		expectedUpdated bool
+		expectedResult  fields
+	}{
+		{"NewValues", fields{}, values, true, expected},
+		{"NoNewValues", expected, values, false, expected},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := new(reader)
+			vStruct := reflect.ValueOf(&tc.s).Elem()
+			for i := 0; i < vStruct.NumField(); i++ {
+				f := fieldInfo{
+					value:   vStruct.Field(i),
+					name:    vStruct.Type().Field(i).Name,
+					listSep: ",",
+				}
+
+				updated, err := r.setFieldValue(f, tc.values[f.name])
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedUpdated, updated, f.name)
+			}
+
+			assert.Equal(t, tc.expectedResult, tc.s)
+		})
+	}
+
+	t.Run("InvalidValues", func(t *testing.T) {
+		s := fields{}
+		vStruct := reflect.ValueOf(&s).Elem()
+
+		invalid := map[string]string{
+			"IP":            "10.0.0.256",
+			"IPPtr":         "invalid",
+			"IPSlice":       "10.0.0.1,invalid",
+			"IPNet":         "10.0.0.1",
+			"Addr":          "10.0.0.256",
+			"PrefixPtr":     "10.0.0.0/33",
+			"AddrPortSlice": "10.0.0.1",
+		}
+
+		for name, val := range invalid {
+			f := fieldInfo{
+				value:   vStruct.FieldByName(name),
+				name:    name,
+				listSep: ",",
+			}
+
+			updated, err := new(reader).setFieldValue(f, val)
+
+			assert.Error(t, err, name)
+			assert.False(t, updated, name)
+		}
+
+		assert.Equal(t, fields{}, s)
+	})
+}
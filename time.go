@@ -0,0 +1,86 @@
+package konfig
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// layouts are the names that can be used in layout tag instead of the layouts themselves.
+var layouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+	"Kitchen":     time.Kitchen,
+}
+
+// ParseTime parses a time using a layout as defined by the time package.
+// layout can also be the name of one of the predefined layouts (i.e. RFC1123 or DateOnly).
+// If layout is empty, RFC 3339 is used.
+func ParseTime(s, layout string) (time.Time, error) {
+	if layout == "" {
+		layout = time.RFC3339
+	} else if l, ok := layouts[layout]; ok {
+		layout = l
+	}
+
+	return time.Parse(layout, s)
+}
+
+// TimeOfDay is a wall-clock time within a day without a date or a time zone (i.e. 09:30).
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// ParseTimeOfDay parses a time of day in either HH:MM or HH:MM:SS formats using a 24-hour clock.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day: %s", s)
+	}
+
+	// Hours can have one digit (i.e. 9:30), but minutes and seconds should have two digits
+	nums := make([]int, 3)
+	for i, part := range parts {
+		if len(part) != 2 && (i > 0 || len(part) != 1) {
+			return TimeOfDay{}, fmt.Errorf("invalid time of day: %s", s)
+		}
+
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return TimeOfDay{}, fmt.Errorf("invalid time of day: %s", s)
+			}
+			nums[i] = nums[i]*10 + int(c-'0')
+		}
+	}
+
+	t := TimeOfDay{Hour: nums[0], Minute: nums[1], Second: nums[2]}
+	if t.Hour > 23 || t.Minute > 59 || t.Second > 59 {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day: %s", s)
+	}
+
+	return t, nil
+}
+
+// String returns the time of day in HH:MM format or HH:MM:SS format if it has seconds.
+func (t TimeOfDay) String() string {
+	if t.Second == 0 {
+		return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// On returns the time of day on the date of a given time in the location of the given time.
+func (t TimeOfDay) On(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, t.Hour, t.Minute, t.Second, 0, date.Location())
+}
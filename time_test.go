@@ -0,0 +1,156 @@
+package konfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             string
+		layout        string
+		expectedTime  time.Time
+		expectedError bool
+	}{
+		{"DefaultLayout", "2024-03-01T09:30:00Z", "", time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), false},
+		{"NamedLayout", "2024-03-01", "DateOnly", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"CustomLayout", "01/03/2024", "02/01/2006", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"Invalid", "2024-03-01", "", time.Time{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tm, err := ParseTime(tc.s, tc.layout)
+
+			assert.True(t, tc.expectedTime.Equal(tm))
+			assert.Equal(t, tc.expectedError, err != nil)
+		})
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		name              string
+		s                 string
+		expectedTimeOfDay TimeOfDay
+		expectedError     error
+	}{
+		{"HoursAndMinutes", "09:30", TimeOfDay{9, 30, 0}, nil},
+		{"SingleDigitHours", "9:30", TimeOfDay{9, 30, 0}, nil},
+		{"WithSeconds", "23:59:59", TimeOfDay{23, 59, 59}, nil},
+		{"Midnight", "00:00", TimeOfDay{}, nil},
+		{"NoMinutes", "09", TimeOfDay{}, errors.New("invalid time of day: 09")},
+		{"TooManyParts", "09:30:00:00", TimeOfDay{}, errors.New("invalid time of day: 09:30:00:00")},
+		{"SingleDigitMinutes", "09:3", TimeOfDay{}, errors.New("invalid time of day: 09:3")},
+		{"NotDigits", "09:3x", TimeOfDay{}, errors.New("invalid time of day: 09:3x")},
+		{"Signed", "+9:30", TimeOfDay{}, errors.New("invalid time of day: +9:30")},
+		{"InvalidHours", "24:00", TimeOfDay{}, errors.New("invalid time of day: 24:00")},
+		{"InvalidMinutes", "12:60", TimeOfDay{}, errors.New("invalid time of day: 12:60")},
+		{"InvalidSeconds", "12:00:60", TimeOfDay{}, errors.New("invalid time of day: 12:00:60")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tod, err := ParseTimeOfDay(tc.s)
+
+			assert.Equal(t, tc.expectedTimeOfDay, tod)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTimeOfDayString(t *testing.T) {
+	tests := []struct {
+		name           string
+		t              TimeOfDay
+		expectedString string
+	}{
+		{"Midnight", TimeOfDay{}, "00:00"},
+		{"NoSeconds", TimeOfDay{9, 5, 0}, "09:05"},
+		{"WithSeconds", TimeOfDay{23, 59, 59}, "23:59:59"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.t.String())
+		})
+	}
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	loc := time.FixedZone("EST", -5*60*60)
+	date := time.Date(2024, 3, 1, 17, 45, 10, 500, loc)
+
+	tm := TimeOfDay{9, 30, 0}.On(date)
+
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 30, 0, 0, loc), tm)
+}
+
+func TestReaderSetTime(t *testing.T) {
+	type fields struct {
+		Time           time.Time
+		TimePtr        *time.Time
+		TimeSlice      []time.Time
+		Location       *time.Location
+		LocationSlice  []*time.Location
+		TimeOfDay      TimeOfDay
+		TimeOfDayPtr   *TimeOfDay
+		TimeOfDaySlice []TimeOfDay
+	}
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tod := TimeOfDay{9, 30, 0}
+
+	tests := []struct {
+		name            string
+		field           string
+		val             string
+		layout          string
+		expectedUpdated bool
+		expectedError   bool
+		expectedResult  fields
+	}{
+		{"Time", "Time", "2024-03-01T00:00:00Z", "", true, false, fields{Time: date}},
+		{"TimeWithLayout", "Time", "2024-03-01", "DateOnly", true, false, fields{Time: date}},
+		{"TimePtr", "TimePtr", "01 Mar 24 00:00 UTC", "RFC822", true, false, fields{TimePtr: &date}},
+		{"TimeSlice", "TimeSlice", "2024-03-01,2024-03-01", "2006-01-02", true, false, fields{TimeSlice: []time.Time{date, date}}},
+		{"InvalidTime", "Time", "2024-03-01", "", false, true, fields{}},
+		{"Location", "Location", "UTC", "", true, false, fields{Location: time.UTC}},
+		{"LocationSlice", "LocationSlice", "UTC,UTC", "", true, false, fields{LocationSlice: []*time.Location{time.UTC, time.UTC}}},
+		{"InvalidLocation", "Location", "Mars/Olympus_Mons", "", false, true, fields{}},
+		{"TimeOfDay", "TimeOfDay", "09:30", "", true, false, fields{TimeOfDay: tod}},
+		{"TimeOfDayPtr", "TimeOfDayPtr", "09:30", "", true, false, fields{TimeOfDayPtr: &tod}},
+		{"TimeOfDaySlice", "TimeOfDaySlice", "09:30,09:30", "", true, false, fields{TimeOfDaySlice: []TimeOfDay{tod, tod}}},
+		{"InvalidTimeOfDay", "TimeOfDay", "9.30", "", false, true, fields{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := fields{}
+			f := fieldInfo{
+				value:   reflect.ValueOf(&s).Elem().FieldByName(tc.field),
+				name:    tc.field,
+				listSep: ",",
+				layout:  tc.layout,
+			}
+
+			updated, err := new(reader).setFieldValue(f, tc.val)
+
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.Equal(t, tc.expectedError, err != nil)
+			assert.Equal(t, tc.expectedResult, s)
+
+			// Setting the same value again does not update the field
+			if tc.expectedUpdated {
+				updated, err := new(reader).setFieldValue(f, tc.val)
+				assert.NoError(t, err)
+				assert.False(t, updated)
+			}
+		})
+	}
+}
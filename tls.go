@@ -0,0 +1,193 @@
+package konfig
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const pemPrefix = "-----BEGIN"
+
+// isTLSType determines whether or not a type (or the type it points to) is one of the supported TLS types.
+func isTLSType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return (t.PkgPath() == "crypto/tls" && t.Name() == "Certificate") ||
+		(t.PkgPath() == "crypto/x509" && t.Name() == "CertPool")
+}
+
+// tlsPaths returns the paths to the files referred to by a value for a TLS type.
+// A value is either PEM-encoded content or a list of paths separated by os.PathListSeparator.
+func tlsPaths(val string) []string {
+	if val == "" || strings.Contains(val, pemPrefix) {
+		return nil
+	}
+
+	return strings.Split(val, string(os.PathListSeparator))
+}
+
+// ParseCertificate parses a certificate chain and its private key from either PEM-encoded content or paths to PEM files.
+// Paths are separated by os.PathListSeparator (i.e. /path/to/tls.crt:/path/to/tls.key).
+// If only one path is given, the file should have both the certificate chain and the private key.
+func ParseCertificate(val string) (*tls.Certificate, error) {
+	paths := tlsPaths(val)
+
+	var certPEM, keyPEM []byte
+
+	switch len(paths) {
+	case 0:
+		certPEM, keyPEM = []byte(val), []byte(val)
+	case 1:
+		b, err := ioutil.ReadFile(paths[0])
+		if err != nil {
+			return nil, err
+		}
+		certPEM, keyPEM = b, b
+	case 2:
+		var err error
+		if certPEM, err = ioutil.ReadFile(paths[0]); err != nil {
+			return nil, err
+		}
+		if keyPEM, err = ioutil.ReadFile(paths[1]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid certificate paths: %s", val)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// ParseCertPool parses a pool of CA certificates from either PEM-encoded content or paths to PEM files.
+// Paths are separated by os.PathListSeparator (i.e. /path/to/ca-1.crt:/path/to/ca-2.crt).
+func ParseCertPool(val string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+
+	paths := tlsPaths(val)
+	if len(paths) == 0 {
+		if !pool.AppendCertsFromPEM([]byte(val)) {
+			return nil, errors.New("no certificate found")
+		}
+		return pool, nil
+	}
+
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificate found in %s", path)
+		}
+	}
+
+	return pool, nil
+}
+
+// certificatesEqual determines whether or not two certificates have the same chain and private key.
+func certificatesEqual(a, b tls.Certificate) bool {
+	if !reflect.DeepEqual(a.Certificate, b.Certificate) {
+		return false
+	}
+
+	if key, ok := a.PrivateKey.(interface{ Equal(crypto.PrivateKey) bool }); ok {
+		return key.Equal(b.PrivateKey)
+	}
+
+	return a.PrivateKey == nil && b.PrivateKey == nil
+}
+
+// ServerTLSConfig creates a TLS configuration for servers.
+// The certificate and the pool of client CAs are read on every handshake,
+// so new values set by Watch are used without restarting the server.
+// If clientCAs is nil, client certificates are not requested.
+// Otherwise, client certificates are required and verified, and handshakes fail while clientCAs returns nil
+// (i.e. the file for client CAs does not exist yet).
+func ServerTLSConfig(cert func() *tls.Certificate, clientCAs func() *x509.CertPool) *tls.Config {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return cert(), nil
+		},
+	}
+
+	if clientCAs != nil {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			pool := clientCAs()
+			if pool == nil {
+				return nil, errors.New("no client CAs")
+			}
+
+			c := config.Clone()
+			c.GetConfigForClient = nil
+			c.ClientCAs = pool
+			return c, nil
+		}
+	}
+
+	return config
+}
+
+// ClientTLSConfig creates a TLS configuration for clients.
+// The certificate and the pool of root CAs are read on every handshake,
+// so new values set by Watch are used without creating new clients.
+// If cert is nil, no client certificate is sent. If rootCAs is nil or returns nil, the system pool is used.
+func ClientTLSConfig(cert func() *tls.Certificate, rootCAs func() *x509.CertPool) *tls.Config {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cert != nil {
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if c := cert(); c != nil {
+				return c, nil
+			}
+			return &tls.Certificate{}, nil
+		}
+	}
+
+	if rootCAs != nil {
+		// The default verification is replaced by one using the current pool of root CAs
+		// See https://pkg.go.dev/crypto/tls#example-Config-VerifyConnection
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(cs tls.ConnectionState) error {
+			opts := x509.VerifyOptions{
+				DNSName:       cs.ServerName,
+				Roots:         rootCAs(),
+				Intermediates: x509.NewCertPool(),
+			}
+
+			if cs.ServerName == "" {
+				return errors.New("no server name")
+			}
+
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no server certificate")
+			}
+
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,351 @@
+package konfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCA         = "testdata/tls/ca.crt"
+	testServerCert = "testdata/tls/server.crt"
+	testServerKey  = "testdata/tls/server.key"
+	testClientCert = "testdata/tls/client.crt"
+	testClientKey  = "testdata/tls/client.key"
+)
+
+func readTestFile(t *testing.T, path string) string {
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func joinPaths(paths ...string) string {
+	return strings.Join(paths, string(os.PathListSeparator))
+}
+
+func TestTLSPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		val      string
+		expected []string
+	}{
+		{"Empty", "", nil},
+		{"PEM", "-----BEGIN CERTIFICATE-----\n...", nil},
+		{"OnePath", "/path/to/tls.pem", []string{"/path/to/tls.pem"}},
+		{"TwoPaths", joinPaths("/path/to/tls.crt", "/path/to/tls.key"), []string{"/path/to/tls.crt", "/path/to/tls.key"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tlsPaths(tc.val))
+		})
+	}
+}
+
+func TestParseCertificate(t *testing.T) {
+	certPEM := readTestFile(t, testServerCert)
+	keyPEM := readTestFile(t, testServerKey)
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString(certPEM + keyPEM)
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		val           string
+		expectedError string
+	}{
+		{"PEM", certPEM + keyPEM, ""},
+		{"OnePath", tmpfile.Name(), ""},
+		{"TwoPaths", joinPaths(testServerCert, testServerKey), ""},
+		{"NoKey", certPEM, "tls: found a certificate rather than a key in the PEM for the private key"},
+		{"MismatchedKey", joinPaths(testServerCert, testClientKey), "tls: private key does not match public key"},
+		{"MissingFile", joinPaths(testServerCert, "/tmp/missing.key"), "open /tmp/missing.key: no such file or directory"},
+		{"TooManyPaths", joinPaths("a", "b", "c"), "invalid certificate paths: " + joinPaths("a", "b", "c")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cert, err := ParseCertificate(tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, cert)
+				assert.Len(t, cert.Certificate, 1)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, cert)
+			}
+		})
+	}
+}
+
+func TestParseCertPool(t *testing.T) {
+	caPEM := readTestFile(t, testCA)
+
+	tests := []struct {
+		name          string
+		val           string
+		expectedError string
+	}{
+		{"PEM", caPEM, ""},
+		{"OnePath", testCA, ""},
+		{"TwoPaths", joinPaths(testCA, testServerCert), ""},
+		{"NoCertificate", "-----BEGIN NOTHING-----", "no certificate found"},
+		{"NoCertificateInFile", joinPaths(testCA, testServerKey), "no certificate found in " + testServerKey},
+		{"MissingFile", "/tmp/missing.crt", "open /tmp/missing.crt: no such file or directory"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool, err := ParseCertPool(tc.val)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, pool)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, pool)
+			}
+		})
+	}
+}
+
+func TestCertificatesEqual(t *testing.T) {
+	server1, err := tls.LoadX509KeyPair(testServerCert, testServerKey)
+	assert.NoError(t, err)
+
+	server2, err := tls.LoadX509KeyPair(testServerCert, testServerKey)
+	assert.NoError(t, err)
+
+	client, err := tls.LoadX509KeyPair(testClientCert, testClientKey)
+	assert.NoError(t, err)
+
+	assert.True(t, certificatesEqual(server1, server2))
+	assert.True(t, certificatesEqual(tls.Certificate{}, tls.Certificate{}))
+	assert.False(t, certificatesEqual(server1, client))
+	assert.False(t, certificatesEqual(tls.Certificate{}, server1))
+}
+
+func TestTLSConfig(t *testing.T) {
+	serverCert, err := ParseCertificate(joinPaths(testServerCert, testServerKey))
+	assert.NoError(t, err)
+
+	clientCert, err := ParseCertificate(joinPaths(testClientCert, testClientKey))
+	assert.NoError(t, err)
+
+	caPool, err := ParseCertPool(testCA)
+	assert.NoError(t, err)
+
+	emptyPool := x509.NewCertPool()
+
+	tests := []struct {
+		name              string
+		clientCert        *tls.Certificate
+		clientCAs         func() *x509.CertPool
+		rootCAs           *x509.CertPool
+		expectServerError bool
+		expectClientError bool
+	}{
+		{"TLS", nil, nil, caPool, false, false},
+		{"MutualTLS", clientCert, func() *x509.CertPool { return caPool }, caPool, false, false},
+		{"NoClientCertificate", nil, func() *x509.CertPool { return caPool }, caPool, true, false},
+		{"NoClientCAs", clientCert, func() *x509.CertPool { return nil }, caPool, true, true},
+		{"UnknownServer", nil, nil, emptyPool, true, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			serverConfig := ServerTLSConfig(func() *tls.Certificate { return serverCert }, tc.clientCAs)
+			clientConfig := ClientTLSConfig(func() *tls.Certificate { return tc.clientCert }, func() *x509.CertPool { return tc.rootCAs })
+			clientConfig.ServerName = "localhost"
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+			defer ln.Close()
+
+			serverErr := make(chan error, 1)
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					serverErr <- err
+					return
+				}
+
+				server := tls.Server(conn, serverConfig)
+				defer server.Close()
+
+				// Client certificates are verified after the client handshake in TLS 1.3, so a read is needed
+				_ = server.SetDeadline(time.Now().Add(5 * time.Second))
+				_, err = server.Read(make([]byte, 1))
+				serverErr <- err
+			}()
+
+			conn, err := net.Dial("tcp", ln.Addr().String())
+			assert.NoError(t, err)
+
+			client := tls.Client(conn, clientConfig)
+			_ = client.SetDeadline(time.Now().Add(5 * time.Second))
+
+			clientErr := client.Handshake()
+			if clientErr == nil {
+				_, _ = client.Write([]byte{0})
+			}
+
+			err = <-serverErr
+			client.Close()
+
+			assert.Equal(t, tc.expectServerError, err != nil, "server error: %v", err)
+			assert.Equal(t, tc.expectClientError, clientErr != nil, "client error: %v", clientErr)
+		})
+	}
+}
+
+func TestPickTLS(t *testing.T) {
+	type tlsConfig struct {
+		Cert      tls.Certificate
+		CertPtr   *tls.Certificate
+		Roots     x509.CertPool
+		RootsPtr  *x509.CertPool
+		CertSlice []tls.Certificate
+	}
+
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString(readTestFile(t, testCA))
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	envs := map[string]string{
+		"CERT":           joinPaths(testServerCert, testServerKey),
+		"CERT_PTR":       readTestFile(t, testClientCert) + readTestFile(t, testClientKey),
+		"ROOTS":          testCA,
+		"ROOTS_PTR_FILE": tmpfile.Name(),
+		"CERT_SLICE":     joinPaths(testServerCert, testServerKey),
+	}
+
+	for name, value := range envs {
+		err := os.Setenv(name, value)
+		assert.NoError(t, err)
+		defer os.Unsetenv(name)
+	}
+
+	c := tlsConfig{}
+	err = Pick(&c)
+	assert.NoError(t, err)
+
+	serverCert, _ := tls.LoadX509KeyPair(testServerCert, testServerKey)
+	clientCert, _ := tls.LoadX509KeyPair(testClientCert, testClientKey)
+	caPool, _ := ParseCertPool(testCA)
+
+	assert.True(t, certificatesEqual(serverCert, c.Cert))
+	assert.True(t, certificatesEqual(clientCert, *c.CertPtr))
+	assert.True(t, caPool.Equal(&c.Roots))
+	assert.True(t, caPool.Equal(c.RootsPtr))
+	assert.Nil(t, c.CertSlice)
+}
+
+func TestWatchTLS(t *testing.T) {
+	type tlsConfig struct {
+		sync.Mutex
+		WatchCert tls.Certificate
+	}
+
+	dir, err := ioutil.TempDir("", "gotest_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+
+	err = ioutil.WriteFile(certPath, []byte(readTestFile(t, testServerCert)), 0644)
+	assert.NoError(t, err)
+
+	err = ioutil.WriteFile(keyPath, []byte(readTestFile(t, testServerKey)), 0644)
+	assert.NoError(t, err)
+
+	err = os.Setenv("WATCH_CERT", joinPaths(certPath, keyPath))
+	assert.NoError(t, err)
+	defer os.Unsetenv("WATCH_CERT")
+
+	c := &tlsConfig{}
+	ch := make(chan Update, 10)
+	close, err := Watch(c, []chan Update{ch}, Polling(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer close()
+
+	serverCert, _ := tls.LoadX509KeyPair(testServerCert, testServerKey)
+	clientCert, _ := tls.LoadX509KeyPair(testClientCert, testClientKey)
+
+	// The initial value
+	<-ch
+
+	c.Lock()
+	assert.True(t, certificatesEqual(serverCert, c.WatchCert))
+	c.Unlock()
+
+	// Rotate the certificate and the key
+	err = ioutil.WriteFile(certPath, []byte(readTestFile(t, testClientCert)), 0644)
+	assert.NoError(t, err)
+
+	err = ioutil.WriteFile(keyPath, []byte(readTestFile(t, testClientKey)), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case update := <-ch:
+		assert.Equal(t, "WatchCert", update.Name)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for the certificate to be rotated")
+	}
+
+	c.Lock()
+	assert.True(t, certificatesEqual(clientCert, c.WatchCert))
+	c.Unlock()
+}
+
+func TestReaderTrackRefs(t *testing.T) {
+	type fields struct {
+		Cert  tls.Certificate
+		CAs   *x509.CertPool
+		Token string
+	}
+
+	s := fields{}
+	v := reflect.ValueOf(&s).Elem()
+
+	r := &reader{
+		filesToFields: map[string]fieldInfo{},
+	}
+
+	certVal := joinPaths("/path/to/tls.crt", "/path/to/tls.key")
+	r.trackRefs(fieldInfo{value: v.Field(0), name: "Cert"}, certVal)
+	r.trackRefs(fieldInfo{value: v.Field(1), name: "CAs"}, "-----BEGIN CERTIFICATE-----")
+	r.trackRefs(fieldInfo{value: v.Field(2), name: "Token"}, "/path/to/token")
+
+	assert.Len(t, r.filesToFields, 2)
+	assert.Equal(t, certVal, r.filesToFields["/path/to/tls.crt"].ref)
+	assert.Equal(t, certVal, r.filesToFields["/path/to/tls.key"].ref)
+	assert.Equal(t, certVal, r.filesToFields["/path/to/tls.key"].watchedValue("content"))
+	assert.Equal(t, "content", fieldInfo{}.watchedValue("content"))
+}
@@ -0,0 +1,311 @@
+package konfig
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const defaultPollInterval = time.Second
+
+// defaultReloadSignals are the signals for reloading configuration files if no signal is specified.
+var defaultReloadSignals = []os.Signal{syscall.SIGHUP}
+
+// applyFunc sets a new value read from a file for a field.
+type applyFunc func(f fieldInfo, val string) error
+
+// fileState is what the poller knows about a file.
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+	sum     [sha256.Size]byte
+}
+
+// equal determines whether or not two states of a file are the same.
+func (s fileState) equal(t fileState) bool {
+	return s.exists == t.exists &&
+		s.modTime.Equal(t.modTime) &&
+		s.size == t.size &&
+		s.sum == t.sum
+}
+
+// readFileState returns the current state of a file alongside its content.
+func readFileState(path string) (fileState, []byte) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fileState{}, nil
+	}
+
+	return fileState{
+		exists:  true,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		sum:     sha256.Sum256(b),
+	}, b
+}
+
+// watchFiles watches the files that values of fields are read from.
+// Every time a file gets a new value, apply is called with the field and the new value.
+// The returned function stops watching the files.
+func (r *reader) watchFiles(apply applyFunc) (func(), error) {
+	paths := make([]string, 0, len(r.filesToFields))
+	for path := range r.filesToFields {
+		paths = append(paths, path)
+	}
+
+	interval := r.pollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	// File system notifications are not delivered for files mounted by Telepresence
+	if r.pollInterval > 0 || (r.telepresence && os.Getenv(envTelepresenceRoot) != "") {
+		return r.pollFiles(paths, interval, apply), nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		r.log(1, "cannot create a watcher, falling back to polling", "error", err)
+		return r.pollFiles(paths, interval, apply), nil
+	}
+
+	// update reads the new value of a file and applies it to its field
+	update := func(path string, f fieldInfo) {
+		// An empty file is treated the same as no value (files are created or truncated before being written)
+		if val, err := r.readFile(path, f.binary); err == nil && val != "" {
+			r.log(3, "received an update", "field", f.name, "source", SourceFile, "path", path, "length", len(val))
+			if err := r.applyValue(apply, f, f.watchedValue(val)); err != nil {
+				r.log(1, "cannot set value", "field", f.name, "path", path, "error", err)
+			}
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				path := filepath.Clean(event.Name)
+				r.log(6, "event received", "op", event.Op.String(), "path", event.Name)
+
+				// We also receive events for other files in the directories of files that do not exist yet
+				if f, ok := r.filesToFields[path]; ok {
+					// Write
+					if event.Op&fsnotify.Write == fsnotify.Write {
+						update(path, f)
+					}
+
+					// Create
+					// A file that did not exist is created in a watched directory.
+					if event.Op&fsnotify.Create == fsnotify.Create {
+						update(path, f)
+
+						// Add a watch for the file, so it can be handled the same as other files
+						if err := watcher.Add(path); err != nil {
+							r.log(1, "cannot watch file", "path", path, "error", err)
+						}
+					}
+
+					// Remove
+					// Kubernetes injects new values from ConfigMaps and Secrets by removing the mounted files and recreating them.
+					// When a watched file is removed, the fsnotify package will remove it from the watcher too.
+					// This if block is a workaround for the aforementioned Kubernetes situation.
+					// See https://github.com/moorara/konfig/issues/47
+					if event.Op&fsnotify.Remove == fsnotify.Remove {
+						// Check if the removed file is already recreated
+						if _, err := os.Stat(path); err == nil {
+							update(path, f)
+
+							// Re-Add a watch for the file
+							if err := watcher.Add(path); err != nil {
+								r.log(1, "cannot watch file", "path", path, "error", err)
+							}
+						} else if err := watcher.Add(filepath.Dir(path)); err != nil {
+							// Otherwise, watch the directory for the file to be created again
+							r.log(1, "cannot watch directory", "path", filepath.Dir(path), "error", err)
+						}
+					}
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				r.log(1, "error watching", "error", err)
+			}
+		}
+	}()
+
+	// Some network and FUSE file systems do not support notifications
+	polled := []string{}
+	dirs := map[string]error{}
+	for _, path := range paths {
+		// If a file does not exist yet, its directory is watched for the file to be created
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			dir := filepath.Dir(path)
+			if _, ok := dirs[dir]; !ok {
+				r.log(6, "watching directory for missing file", "dir", dir, "path", path)
+				dirs[dir] = watcher.Add(dir)
+			}
+
+			if err := dirs[dir]; err != nil {
+				r.log(1, "cannot watch directory, falling back to polling", "dir", dir, "path", path, "error", err)
+				polled = append(polled, path)
+			}
+
+			continue
+		}
+
+		if err := watcher.Add(path); err != nil {
+			r.log(1, "cannot watch file, falling back to polling", "path", path, "error", err)
+			polled = append(polled, path)
+		}
+	}
+
+	stopPolling := func() {}
+	if len(polled) > 0 {
+		stopPolling = r.pollFiles(polled, interval, apply)
+	}
+
+	stop := func() {
+		watcher.Close()
+		stopPolling()
+	}
+
+	return stop, nil
+}
+
+// pollFiles checks the modification time, size, and content hash of files on an interval.
+// Every time a file gets a new value, apply is called with the field and the new value.
+// The returned function stops polling the files.
+func (r *reader) pollFiles(paths []string, interval time.Duration, apply applyFunc) func() {
+	r.log(2, "polling files", "files", len(paths), "interval", interval.String())
+
+	states := map[string]fileState{}
+	for _, path := range paths {
+		states[path], _ = readFileState(path)
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				for _, path := range paths {
+					state, b := readFileState(path)
+					if state.equal(states[path]) {
+						continue
+					}
+
+					states[path] = state
+					r.log(6, "change detected", "path", path)
+
+					// An empty file is treated the same as no value (files are created or truncated before being written)
+					if f, ok := r.filesToFields[path]; ok {
+						val := r.fileValue(b, f.binary)
+						if val == "" {
+							continue
+						}
+
+						r.log(3, "received an update", "field", f.name, "source", SourceFile, "path", path, "length", len(val))
+						if err := r.applyValue(apply, f, f.watchedValue(val)); err != nil {
+							r.log(1, "cannot set value", "field", f.name, "path", path, "error", err)
+						}
+					}
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// The returned function can be called more than once
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// reload reads the files that values of fields are read from again and applies their values.
+// An error is returned if any value cannot be set, but all files are read regardless.
+func (r *reader) reload(apply applyFunc) error {
+	r.log(3, "reloading files", "files", len(r.filesToFields))
+
+	var firstErr error
+	for path, f := range r.filesToFields {
+		// An empty or missing file is treated the same as no value
+		if val, err := r.readFile(path, f.binary); err == nil && val != "" {
+			if err := r.applyValue(apply, f, f.watchedValue(val)); err != nil {
+				r.log(1, "cannot set value", "field", f.name, "path", path, "error", err)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// watchSignals reloads the values read from files every time the process receives any of the reload signals.
+// The returned function stops listening for the signals.
+func (r *reader) watchSignals(apply applyFunc) func() {
+	if len(r.reloadSignals) == 0 {
+		return func() {}
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, r.reloadSignals...)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case sig := <-sigs:
+				r.log(3, "signal received", "signal", sig.String())
+				_ = r.reload(apply)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// The returned function can be called more than once
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(sigs)
+			close(done)
+			<-stopped
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package konfig
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFileState(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("foo")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	state, b := readFileState(tmpfile.Name())
+	assert.True(t, state.exists)
+	assert.Equal(t, int64(3), state.size)
+	assert.Equal(t, []byte("foo"), b)
+	assert.True(t, state.equal(state))
+
+	missing, b := readFileState(tmpfile.Name() + "_missing")
+	assert.False(t, missing.exists)
+	assert.Nil(t, b)
+	assert.False(t, state.equal(missing))
+}
+
+func TestReaderPollFiles(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("foo")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	r := &reader{
+		filesToFields: map[string]fieldInfo{
+			tmpfile.Name(): {name: "Field"},
+		},
+	}
+
+	vals := make(chan string, 10)
+	stop := r.pollFiles([]string{tmpfile.Name()}, 10*time.Millisecond, func(f fieldInfo, val string) error {
+		assert.Equal(t, "Field", f.name)
+		vals <- val
+		return nil
+	})
+	defer stop()
+
+	err = ioutil.WriteFile(tmpfile.Name(), []byte("bar\n"), 0644)
+	assert.NoError(t, err)
+
+	select {
+	case val := <-vals:
+		assert.Equal(t, "bar", val)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for a new value")
+	}
+
+	// Polling can be stopped more than once
+	stop()
+	stop()
+}
+
+func TestReaderWatchFilesEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *reader
+	}{
+		{"Notifications", &reader{}},
+		{"Polling", &reader{pollInterval: 10 * time.Millisecond}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpfile, err := ioutil.TempFile("", "gotest_")
+			assert.NoError(t, err)
+			defer os.Remove(tmpfile.Name())
+
+			_, err = tmpfile.WriteString("foo")
+			assert.NoError(t, err)
+
+			err = tmpfile.Close()
+			assert.NoError(t, err)
+
+			tc.r.filesToFields = map[string]fieldInfo{
+				tmpfile.Name(): {name: "Field"},
+			}
+
+			vals := make(chan string, 10)
+			stop, err := tc.r.watchFiles(func(f fieldInfo, val string) error {
+				vals <- val
+				return nil
+			})
+			assert.NoError(t, err)
+			defer stop()
+
+			// Files are truncated before being written, so an empty file is not a new value
+			err = os.Truncate(tmpfile.Name(), 0)
+			assert.NoError(t, err)
+			time.Sleep(50 * time.Millisecond)
+
+			err = ioutil.WriteFile(tmpfile.Name(), []byte("bar\n"), 0644)
+			assert.NoError(t, err)
+
+			select {
+			case val := <-vals:
+				assert.Equal(t, "bar", val)
+			case <-time.After(time.Second):
+				assert.Fail(t, "timed out waiting for a new value")
+			}
+		})
+	}
+}
+
+func TestReaderReload(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("foo")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	r := &reader{
+		filesToFields: map[string]fieldInfo{
+			tmpfile.Name():              {name: "Field"},
+			tmpfile.Name() + "_missing": {name: "Missing"},
+		},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		vals := map[string]string{}
+		err := r.reload(func(f fieldInfo, val string) error {
+			vals[f.name] = val
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"Field": "foo"}, vals)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		err := r.reload(func(f fieldInfo, val string) error {
+			return errors.New("invalid value")
+		})
+
+		assert.Equal(t, errors.New("invalid value"), err)
+	})
+}
+
+func TestReaderWatchSignals(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "gotest_")
+	assert.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	_, err = tmpfile.WriteString("foo")
+	assert.NoError(t, err)
+
+	err = tmpfile.Close()
+	assert.NoError(t, err)
+
+	t.Run("NoSignal", func(t *testing.T) {
+		r := &reader{}
+		stop := r.watchSignals(nil)
+		stop()
+	})
+
+	t.Run("SIGHUP", func(t *testing.T) {
+		r := &reader{
+			reloadSignals: []os.Signal{syscall.SIGHUP},
+			filesToFields: map[string]fieldInfo{
+				tmpfile.Name(): {name: "Field"},
+			},
+		}
+
+		vals := make(chan string, 1)
+		stop := r.watchSignals(func(f fieldInfo, val string) error {
+			vals <- val
+			return nil
+		})
+		defer stop()
+
+		p, err := os.FindProcess(os.Getpid())
+		assert.NoError(t, err)
+
+		err = p.Signal(syscall.SIGHUP)
+		assert.NoError(t, err)
+
+		select {
+		case val := <-vals:
+			assert.Equal(t, "foo", val)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timed out waiting for reloading")
+		}
+	})
+
+	t.Run("StopTwice", func(t *testing.T) {
+		r := &reader{
+			reloadSignals: []os.Signal{syscall.SIGHUP},
+		}
+
+		stop := r.watchSignals(nil)
+		stop()
+		stop()
+	})
+}